@@ -0,0 +1,236 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/confirm"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	callArgs   []string
+	callJSON   string
+	callOutput string
+	callYes    bool
+
+	callCmd = &cobra.Command{
+		Use:   "call <tool>",
+		Short: "Call a tool of an in-process GKE MCP Server and print its result.",
+		Example: `  gke-mcp call list_clusters --arg project_id=my-project --arg location=-
+  gke-mcp call get_k8s_resource --json '{"project_id": "my-project", "location": "us-central1", "cluster_name": "my-cluster", "resourceType": "pod"}'`,
+		Args: cobra.ExactArgs(1),
+		Run:  runCallCmd,
+	}
+)
+
+func init() {
+	callCmd.Flags().StringArrayVar(&callArgs, "arg", nil, "tool argument as key=value; the value is parsed as JSON unless the tool declares the argument as a string; may be repeated")
+	callCmd.Flags().StringVar(&callJSON, "json", "", "tool arguments as a JSON object; --arg values take precedence")
+	callCmd.Flags().StringVarP(&callOutput, "output", "o", "text", "output format: text (text content, or structured content if there is none) or json (the whole result)")
+	callCmd.Flags().BoolVar(&enableDeleteTools, "enable-delete-tools", false, "Enable destructive delete tools (delete_cluster, delete_node_pool)")
+	callCmd.Flags().BoolVar(&readOnly, "read-only", false, "Only register tools annotated as read-only; overrides --enable-delete-tools")
+	callCmd.Flags().BoolVarP(&callYes, "yes", "y", false, "approve delete, update and cancel operations without prompting for confirmation")
+	addConfigFlags(callCmd)
+	addClusterFlags(callCmd)
+	rootCmd.AddCommand(callCmd)
+}
+
+func runCallCmd(cmd *cobra.Command, args []string) {
+	if callOutput != "text" && callOutput != "json" {
+		log.Fatalf("Unknown output format %q: must be text or json\n", callOutput)
+	}
+	file, toolFilter := parseConfigFlags(cmd)
+	endpointType, impersonationOpts := parseClusterFlags()
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	configOpts := append([]config.Option{config.WithReadOnly(readOnly), config.WithToolFilter(toolFilter), config.WithFile(file)},
+		clusterConfigOptions(endpointType, impersonationOpts)...)
+	c := config.New(version, enableDeleteTools, configOpts...)
+	clientOpts := &mcp.ClientOptions{ElicitationHandler: promptConfirmation(os.Stdin, os.Stderr, callYes)}
+	result, err := callTool(ctx, c, clientOpts, args[0], callJSON, callArgs)
+	if err != nil {
+		log.Fatalf("Failed to call %s: %v\n", args[0], err)
+	}
+	if err := printCallResult(os.Stdout, result, callOutput); err != nil {
+		log.Fatalf("Failed to print result: %v\n", err)
+	}
+	if result.IsError {
+		os.Exit(1)
+	}
+}
+
+// callTool builds the server for c, connects a client with clientOpts to it
+// over an in-memory transport and calls the named tool.
+func callTool(ctx context.Context, c *config.Config, clientOpts *mcp.ClientOptions, name, jsonArgs string, pairs []string) (*mcp.CallToolResult, error) {
+	s, err := newServer(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+	session, err := connectInMemory(ctx, s, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = session.Close() }()
+
+	var tool *mcp.Tool
+	var names []string
+	for t, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		if t.Name == name {
+			tool = t
+		}
+		names = append(names, t.Name)
+	}
+	if tool == nil {
+		return nil, fmt.Errorf("unknown tool %q; available tools: %s", name, strings.Join(names, ", "))
+	}
+
+	arguments, err := callArguments(tool, jsonArgs, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: arguments})
+}
+
+// connectInMemory connects a client with opts to s over an in-memory
+// transport. Closing the returned session also ends the server's session.
+func connectInMemory(ctx context.Context, s *mcp.Server, opts *mcp.ClientOptions) (*mcp.ClientSession, error) {
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := s.Connect(ctx, serverTransport, nil); err != nil {
+		return nil, fmt.Errorf("failed to connect server: %w", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "gke-mcp", Version: version}, opts)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect client: %w", err)
+	}
+	return session, nil
+}
+
+// promptConfirmation returns an elicitation handler that shows the
+// confirmation message on out and approves the operation if the user answers
+// yes on in, or unconditionally if yes is set.
+func promptConfirmation(in io.Reader, out io.Writer, yes bool) func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+	reader := bufio.NewReader(in)
+	return func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		_, _ = fmt.Fprintln(out, req.Params.Message)
+		if yes {
+			_, _ = fmt.Fprintln(out, "Approved by --yes.")
+		} else {
+			_, _ = fmt.Fprint(out, "Proceed? [y/N] ")
+			// A read error, such as EOF on a closed stdin, counts as no.
+			answer, _ := reader.ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				return &mcp.ElicitResult{Action: "decline"}, nil
+			}
+		}
+		return &mcp.ElicitResult{Action: "accept", Content: map[string]any{confirm.Field: true}}, nil
+	}
+}
+
+// callArguments merges the --json object with the --arg key=value pairs.
+// Values are decoded as JSON so that numbers, booleans and arrays can be
+// passed, except for arguments the tool's input schema declares as strings,
+// which are passed verbatim.
+func callArguments(tool *mcp.Tool, jsonArgs string, pairs []string) (map[string]any, error) {
+	arguments := make(map[string]any)
+	if jsonArgs != "" {
+		if err := json.Unmarshal([]byte(jsonArgs), &arguments); err != nil {
+			return nil, fmt.Errorf("invalid --json arguments: %w", err)
+		}
+	}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --arg %q: must be key=value", pair)
+		}
+		if isStringProperty(tool.InputSchema, key) {
+			arguments[key] = value
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			// Not JSON; let the server's schema validation report a mismatch.
+			v = value
+		}
+		arguments[key] = v
+	}
+	return arguments, nil
+}
+
+// isStringProperty reports whether the JSON schema declares the property as a
+// string, or doesn't declare it at all.
+func isStringProperty(schema any, name string) bool {
+	s, _ := schema.(map[string]any)
+	props, _ := s["properties"].(map[string]any)
+	prop, ok := props[name].(map[string]any)
+	if !ok {
+		return true
+	}
+	switch t := prop["type"].(type) {
+	case string:
+		return t == "string"
+	case []any:
+		for _, v := range t {
+			if v == "string" {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// printCallResult writes the result in the given format.
+func printCallResult(w io.Writer, result *mcp.CallToolResult, format string) error {
+	if format == "json" {
+		return writeIndentedJSON(w, result)
+	}
+	var printed bool
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			if _, err := fmt.Fprintln(w, text.Text); err != nil {
+				return err
+			}
+			printed = true
+		}
+	}
+	if !printed && result.StructuredContent != nil {
+		return writeIndentedJSON(w, result.StructuredContent)
+	}
+	return nil
+}
+
+func writeIndentedJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/confirm"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/impersonation"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/k8s"
+	"github.com/google/go-cmp/cmp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/client-go/rest"
+)
+
+// fakeCredentials points Application Default Credentials at a dummy file so
+// that Google API clients can be constructed without contacting any server.
+func fakeCredentials(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "adc.json")
+	creds := `{"type": "authorized_user", "client_id": "id", "client_secret": "secret", "refresh_token": "token"}`
+	if err := os.WriteFile(path, []byte(creds), 0600); err != nil {
+		t.Fatalf("failed to write credentials: %v", err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+}
+
+func TestCallArguments(t *testing.T) {
+	tool := &mcp.Tool{
+		Name: "get_k8s_resource",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"project_id": map[string]any{"type": "string"},
+				"limit":      map[string]any{"type": "integer"},
+				"follow":     map[string]any{"type": []any{"boolean", "null"}},
+				"labels":     map[string]any{"type": "array"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		jsonArgs string
+		pairs    []string
+		want     map[string]any
+		wantErr  string
+	}{
+		{
+			name:  "string property kept verbatim",
+			pairs: []string{"project_id=123", "name=true"},
+			want:  map[string]any{"project_id": "123", "name": "true"},
+		},
+		{
+			name:  "typed properties decoded as JSON",
+			pairs: []string{"limit=10", "follow=true", `labels=["a","b"]`},
+			want:  map[string]any{"limit": float64(10), "follow": true, "labels": []any{"a", "b"}},
+		},
+		{
+			name:  "invalid JSON passed as string",
+			pairs: []string{"limit=ten"},
+			want:  map[string]any{"limit": "ten"},
+		},
+		{
+			name:     "arg overrides json",
+			jsonArgs: `{"project_id": "from-json", "limit": 5}`,
+			pairs:    []string{"project_id=from-arg", "value=a=b"},
+			want:     map[string]any{"project_id": "from-arg", "limit": float64(5), "value": "a=b"},
+		},
+		{
+			name:    "missing separator",
+			pairs:   []string{"project_id"},
+			wantErr: "must be key=value",
+		},
+		{
+			name:     "invalid json",
+			jsonArgs: `[1]`,
+			wantErr:  "invalid --json arguments",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := callArguments(tool, tc.jsonArgs, tc.pairs)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("callArguments() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("callArguments() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("callArguments() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrintCallResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *mcp.CallToolResult
+		format string
+		want   string
+	}{
+		{
+			name: "text",
+			result: &mcp.CallToolResult{
+				Content:           []mcp.Content{&mcp.TextContent{Text: "line one"}, &mcp.TextContent{Text: "line two"}},
+				StructuredContent: map[string]any{"ignored": true},
+			},
+			format: "text",
+			want:   "line one\nline two\n",
+		},
+		{
+			name:   "structured only",
+			result: &mcp.CallToolResult{StructuredContent: map[string]any{"clusters": []any{"a"}}},
+			format: "text",
+			want:   "{\n  \"clusters\": [\n    \"a\"\n  ]\n}\n",
+		},
+		{
+			name:   "json",
+			result: &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "denied"}}, IsError: true},
+			format: "json",
+			want:   "{\n  \"content\": [\n    {\n      \"type\": \"text\",\n      \"text\": \"denied\"\n    }\n  ],\n  \"isError\": true\n}\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := printCallResult(&buf, tc.result, tc.format); err != nil {
+				t.Fatalf("printCallResult() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, buf.String()); diff != "" {
+				t.Errorf("printCallResult() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCallToolUnknown(t *testing.T) {
+	fakeCredentials(t)
+	c := config.NewTestConfig("test-project", "us-central1", "vertex-ai", "gemini-2.5-pro")
+
+	_, err := callTool(context.Background(), c, nil, "no_such_tool", "", nil)
+	if err == nil || !strings.Contains(err.Error(), `unknown tool "no_such_tool"`) || !strings.Contains(err.Error(), "list_clusters") {
+		t.Errorf("callTool() error = %v, want unknown tool listing the available tools", err)
+	}
+}
+
+func TestPromptConfirmation(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		yes        bool
+		wantAction string
+	}{
+		{name: "yes", input: "y\n", wantAction: "accept"},
+		{name: "yes word", input: " Yes \n", wantAction: "accept"},
+		{name: "no", input: "n\n", wantAction: "decline"},
+		{name: "empty", input: "\n", wantAction: "decline"},
+		{name: "eof", input: "", wantAction: "decline"},
+		{name: "flag", input: "", yes: true, wantAction: "accept"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			handler := promptConfirmation(strings.NewReader(tc.input), &out, tc.yes)
+			res, err := handler(context.Background(), &mcp.ElicitRequest{Params: &mcp.ElicitParams{Message: "Delete cluster c?"}})
+			if err != nil {
+				t.Fatalf("handler failed: %v", err)
+			}
+			if res.Action != tc.wantAction {
+				t.Errorf("Action = %q, want %q", res.Action, tc.wantAction)
+			}
+			if tc.wantAction == "accept" && res.Content[confirm.Field] != true {
+				t.Errorf("Content = %v, want %s set to true", res.Content, confirm.Field)
+			}
+			if !strings.Contains(out.String(), "Delete cluster c?") {
+				t.Errorf("prompt %q does not show the message", out.String())
+			}
+		})
+	}
+}
+
+func TestClusterConfigOptions(t *testing.T) {
+	oldKubeconfig, oldKubeContext := kubeconfig, kubeContext
+	t.Cleanup(func() { kubeconfig, kubeContext = oldKubeconfig, oldKubeContext })
+	kubeconfig, kubeContext = "/tmp/kubeconfig", "kind-kind"
+
+	opts := clusterConfigOptions(k8s.EndpointDNS, impersonation.Options{KubernetesUser: "agent", KubernetesGroups: []string{"readers"}})
+	c := config.NewTestConfig("p", "l", "vertex-ai", "m", opts...)
+	if c.ClusterEndpoint() != string(k8s.EndpointDNS) || c.Kubeconfig() != "/tmp/kubeconfig" || c.KubeContext() != "kind-kind" {
+		t.Errorf("config = %q, %q, %q, want the cluster flags", c.ClusterEndpoint(), c.Kubeconfig(), c.KubeContext())
+	}
+	cfg := &rest.Config{}
+	c.ConfigureKubernetes(cfg)
+	if cfg.Impersonate.UserName != "agent" || !slices.Equal(cfg.Impersonate.Groups, []string{"readers"}) {
+		t.Errorf("Impersonate = %+v, want user agent and group readers", cfg.Impersonate)
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/apps"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+	"google.golang.org/api/option"
+)
+
+const (
+	conditionDeleteTools = "--enable-delete-tools"
+	conditionApps        = "client supports MCP Apps, or mock mode"
+)
+
+var (
+	catalogOutput string
+
+	catalogCmd = &cobra.Command{
+		Use:   "tools",
+		Short: "List every tool, prompt and resource the GKE MCP Server can register.",
+		Long: `List every tool, prompt and resource the GKE MCP Server can register, with
+their descriptions, input schemas and annotations. Items that are only
+registered under some condition, such as delete tools and MCP Apps, are
+included and the condition is shown. --read-only is not applied, but the
+tool filters of --enable-tools, --disable-tools and the --config file are.`,
+		Args: cobra.NoArgs,
+		Run:  runCatalogCmd,
+	}
+)
+
+func init() {
+	catalogCmd.Flags().StringVarP(&catalogOutput, "output", "o", "table", "output format: table, json or markdown")
+	addConfigFlags(catalogCmd)
+	rootCmd.AddCommand(catalogCmd)
+}
+
+// catalog describes everything the server can register.
+type catalog struct {
+	Tools             []catalogTool             `json:"tools"`
+	Prompts           []catalogPrompt           `json:"prompts"`
+	Resources         []catalogResource         `json:"resources"`
+	ResourceTemplates []catalogResourceTemplate `json:"resourceTemplates"`
+}
+
+type catalogTool struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description,omitempty"`
+	InputSchema  any                  `json:"inputSchema,omitempty"`
+	OutputSchema any                  `json:"outputSchema,omitempty"`
+	Annotations  *mcp.ToolAnnotations `json:"annotations,omitempty"`
+	// Condition is empty for items that are always registered.
+	Condition string `json:"condition,omitempty"`
+}
+
+type catalogPrompt struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Arguments   []*mcp.PromptArgument `json:"arguments,omitempty"`
+	Condition   string                `json:"condition,omitempty"`
+}
+
+type catalogResource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MIMEType    string `json:"mimeType,omitempty"`
+	Condition   string `json:"condition,omitempty"`
+}
+
+type catalogResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MIMEType    string `json:"mimeType,omitempty"`
+	Condition   string `json:"condition,omitempty"`
+}
+
+func runCatalogCmd(cmd *cobra.Command, _ []string) {
+	var write func(io.Writer, *catalog) error
+	switch catalogOutput {
+	case "table":
+		write = writeCatalogTable
+	case "json":
+		write = func(w io.Writer, cat *catalog) error { return writeIndentedJSON(w, cat) }
+	case "markdown":
+		write = writeCatalogMarkdown
+	default:
+		log.Fatalf("Unknown output format %q: must be table, json or markdown\n", catalogOutput)
+	}
+
+	file, toolFilter := parseConfigFlags(cmd)
+	cat, err := buildCatalog(cmd.Context(), catalogConfig(file, toolFilter))
+	if err != nil {
+		log.Fatalf("Failed to build catalog: %v\n", err)
+	}
+	if err := write(os.Stdout, cat); err != nil {
+		log.Fatalf("Failed to write catalog: %v\n", err)
+	}
+}
+
+// catalogConfig returns a function that returns the configuration of the
+// servers that the catalog is built from, with the configuration file and
+// tool filter given. The servers are never called, so they need no
+// credentials, and they have no default project but that of the file, so that
+// the catalog does not depend on the machine.
+func catalogConfig(file *config.File, toolFilter config.ToolFilter) func(enableDeleteTools bool) *config.Config {
+	return func(enableDeleteTools bool) *config.Config {
+		return config.New(version, enableDeleteTools,
+			config.WithFile(file),
+			config.WithToolFilter(toolFilter),
+			config.WithoutLocalDefaults(),
+			config.WithGoogleClientOptions(option.WithoutAuthentication()))
+	}
+}
+
+// buildCatalog lists what servers built with increasingly permissive settings
+// register. Items are attributed to the first setting that registers them.
+func buildCatalog(ctx context.Context, newConfig func(enableDeleteTools bool) *config.Config) (*catalog, error) {
+	variants := []struct {
+		condition         string
+		enableDeleteTools bool
+		apps              bool
+	}{
+		{condition: ""},
+		{condition: conditionDeleteTools, enableDeleteTools: true},
+		{condition: conditionApps, enableDeleteTools: true, apps: true},
+	}
+
+	cat := &catalog{}
+	seen := make(map[string]bool)
+	for _, v := range variants {
+		c := newConfig(v.enableDeleteTools)
+		s, err := newServer(ctx, c, "")
+		if err != nil {
+			return nil, err
+		}
+		if v.apps && !c.MockMode() {
+			if err := apps.InstallApps(ctx, s, c); err != nil {
+				return nil, fmt.Errorf("failed to install apps: %w", err)
+			}
+		}
+		if err := cat.add(ctx, s, v.condition, seen); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(cat.Tools, func(a, b catalogTool) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(cat.Prompts, func(a, b catalogPrompt) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(cat.Resources, func(a, b catalogResource) int { return cmp.Compare(a.URI, b.URI) })
+	slices.SortFunc(cat.ResourceTemplates, func(a, b catalogResourceTemplate) int { return cmp.Compare(a.URITemplate, b.URITemplate) })
+	return cat, nil
+}
+
+// add appends the items registered by s that are not yet in seen.
+func (cat *catalog) add(ctx context.Context, s *mcp.Server, condition string, seen map[string]bool) error {
+	session, err := connectInMemory(ctx, s, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	isNew := func(kind, key string) bool {
+		if seen[kind+":"+key] {
+			return false
+		}
+		seen[kind+":"+key] = true
+		return true
+	}
+	for t, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		if isNew("tool", t.Name) {
+			cat.Tools = append(cat.Tools, catalogTool{
+				Name:         t.Name,
+				Description:  t.Description,
+				InputSchema:  t.InputSchema,
+				OutputSchema: t.OutputSchema,
+				Annotations:  t.Annotations,
+				Condition:    condition,
+			})
+		}
+	}
+	for p, err := range session.Prompts(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		if isNew("prompt", p.Name) {
+			cat.Prompts = append(cat.Prompts, catalogPrompt{
+				Name:        p.Name,
+				Description: p.Description,
+				Arguments:   p.Arguments,
+				Condition:   condition,
+			})
+		}
+	}
+	for r, err := range session.Resources(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list resources: %w", err)
+		}
+		if isNew("resource", r.URI) {
+			cat.Resources = append(cat.Resources, catalogResource{
+				URI:         r.URI,
+				Name:        r.Name,
+				Description: r.Description,
+				MIMEType:    r.MIMEType,
+				Condition:   condition,
+			})
+		}
+	}
+	for r, err := range session.ResourceTemplates(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list resource templates: %w", err)
+		}
+		if isNew("template", r.URITemplate) {
+			cat.ResourceTemplates = append(cat.ResourceTemplates, catalogResourceTemplate{
+				URITemplate: r.URITemplate,
+				Name:        r.Name,
+				Description: r.Description,
+				MIMEType:    r.MIMEType,
+				Condition:   condition,
+			})
+		}
+	}
+	return nil
+}
+
+// schemaParam is a top-level property of a tool's input schema.
+type schemaParam struct {
+	name        string
+	typ         string
+	required    bool
+	description string
+}
+
+// schemaParams returns the top-level properties of a JSON schema, required
+// ones first.
+func schemaParams(schema any) []schemaParam {
+	s, _ := schema.(map[string]any)
+	props, _ := s["properties"].(map[string]any)
+	required := make(map[string]bool)
+	if req, ok := s["required"].([]any); ok {
+		for _, name := range req {
+			if name, ok := name.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	var params []schemaParam
+	for name, p := range props {
+		prop, _ := p.(map[string]any)
+		param := schemaParam{name: name, required: required[name]}
+		param.description, _ = prop["description"].(string)
+		switch t := prop["type"].(type) {
+		case string:
+			param.typ = t
+		case []any:
+			var types []string
+			for _, v := range t {
+				if v, ok := v.(string); ok {
+					types = append(types, v)
+				}
+			}
+			param.typ = strings.Join(types, "|")
+		}
+		params = append(params, param)
+	}
+	slices.SortFunc(params, func(a, b schemaParam) int {
+		if a.required != b.required {
+			if a.required {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.name, b.name)
+	})
+	return params
+}
+
+// annotationHints returns the tool annotations that are set, such as
+// "read-only" and "idempotent".
+func annotationHints(a *mcp.ToolAnnotations) []string {
+	if a == nil {
+		return nil
+	}
+	var hints []string
+	if a.ReadOnlyHint {
+		hints = append(hints, "read-only")
+	}
+	if a.DestructiveHint != nil && *a.DestructiveHint {
+		hints = append(hints, "destructive")
+	}
+	if a.IdempotentHint {
+		hints = append(hints, "idempotent")
+	}
+	if a.OpenWorldHint != nil && *a.OpenWorldHint {
+		hints = append(hints, "open-world")
+	}
+	return hints
+}
+
+// firstLine returns the first line of a possibly multi-line description.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func writeCatalogTable(w io.Writer, cat *catalog) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "KIND\tNAME\tPARAMETERS\tANNOTATIONS\tCONDITION\tDESCRIPTION")
+	for _, t := range cat.Tools {
+		var params []string
+		for _, p := range schemaParams(t.InputSchema) {
+			if p.required {
+				params = append(params, p.name+"*")
+			} else {
+				params = append(params, p.name)
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "tool\t%s\t%s\t%s\t%s\t%s\n", t.Name, orDash(strings.Join(params, ",")),
+			orDash(strings.Join(annotationHints(t.Annotations), ",")), orDash(t.Condition), firstLine(t.Description))
+	}
+	for _, p := range cat.Prompts {
+		var args []string
+		for _, a := range p.Arguments {
+			if a.Required {
+				args = append(args, a.Name+"*")
+			} else {
+				args = append(args, a.Name)
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "prompt\t%s\t%s\t-\t%s\t%s\n", p.Name, orDash(strings.Join(args, ",")), orDash(p.Condition), firstLine(p.Description))
+	}
+	for _, r := range cat.Resources {
+		_, _ = fmt.Fprintf(tw, "resource\t%s\t-\t-\t%s\t%s\n", r.URI, orDash(r.Condition), firstLine(r.Description))
+	}
+	for _, r := range cat.ResourceTemplates {
+		_, _ = fmt.Fprintf(tw, "template\t%s\t-\t-\t%s\t%s\n", r.URITemplate, orDash(r.Condition), firstLine(r.Description))
+	}
+	return tw.Flush()
+}
+
+// markdownCell escapes text for use in a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func writeCatalogMarkdown(w io.Writer, cat *catalog) error {
+	var b strings.Builder
+	b.WriteString("## Tools\n")
+	for _, t := range cat.Tools {
+		fmt.Fprintf(&b, "\n### `%s`\n\n%s\n", t.Name, strings.TrimSpace(t.Description))
+		var details []string
+		if hints := annotationHints(t.Annotations); len(hints) > 0 {
+			details = append(details, "- Annotations: "+strings.Join(hints, ", "))
+		}
+		if t.Condition != "" {
+			details = append(details, "- Registered only with: "+t.Condition)
+		}
+		if len(details) > 0 {
+			fmt.Fprintf(&b, "\n%s\n", strings.Join(details, "\n"))
+		}
+		params := schemaParams(t.InputSchema)
+		if len(params) == 0 {
+			b.WriteString("\nNo parameters.\n")
+			continue
+		}
+		b.WriteString("\n| Parameter | Type | Required | Description |\n| --- | --- | --- | --- |\n")
+		for _, p := range params {
+			required := "no"
+			if p.required {
+				required = "yes"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", p.name, p.typ, required, markdownCell(p.description))
+		}
+	}
+
+	b.WriteString("\n## Prompts\n\n| Prompt | Arguments | Condition | Description |\n| --- | --- | --- | --- |\n")
+	for _, p := range cat.Prompts {
+		var args []string
+		for _, a := range p.Arguments {
+			if a.Required {
+				args = append(args, fmt.Sprintf("`%s` (required)", a.Name))
+			} else {
+				args = append(args, fmt.Sprintf("`%s`", a.Name))
+			}
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", p.Name, orDash(strings.Join(args, ", ")), orDash(p.Condition), orDash(markdownCell(p.Description)))
+	}
+
+	b.WriteString("\n## Resources\n\n| URI | MIME type | Condition | Description |\n| --- | --- | --- | --- |\n")
+	for _, r := range cat.Resources {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", r.URI, orDash(r.MIMEType), orDash(r.Condition), orDash(markdownCell(r.Description)))
+	}
+	for _, r := range cat.ResourceTemplates {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", r.URITemplate, orDash(r.MIMEType), orDash(r.Condition), orDash(markdownCell(r.Description)))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestBuildCatalog(t *testing.T) {
+	// The catalog needs no credentials, and does not list the clusters of the
+	// local default project.
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv("GKE_MCP_MOCK", "")
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "test-project")
+	cat, err := buildCatalog(context.Background(), catalogConfig(nil, config.ToolFilter{}))
+	if err != nil {
+		t.Fatalf("buildCatalog() failed: %v", err)
+	}
+
+	conditions := make(map[string]string)
+	for _, tool := range cat.Tools {
+		conditions[tool.Name] = tool.Condition
+	}
+	want := map[string]string{
+		"list_clusters":    "",
+		"update_cluster":   "",
+		"delete_cluster":   conditionDeleteTools,
+		"delete_node_pool": conditionDeleteTools,
+		"dropdown":         conditionApps,
+	}
+	for name, condition := range want {
+		got, ok := conditions[name]
+		if !ok {
+			t.Errorf("tool %s missing from catalog", name)
+		} else if got != condition {
+			t.Errorf("tool %s condition = %q, want %q", name, got, condition)
+		}
+	}
+
+	var resources []string
+	for _, r := range cat.Resources {
+		if r.Condition == "" {
+			resources = append(resources, r.URI)
+		}
+	}
+	if diff := cmp.Diff([]string{geminiInstructionsURI}, resources); diff != "" {
+		t.Errorf("unconditional resources mismatch (-want +got):\n%s", diff)
+	}
+	if len(cat.Prompts) == 0 {
+		t.Error("catalog has no prompts")
+	}
+}
+
+func TestBuildCatalogConfigFile(t *testing.T) {
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv("GKE_MCP_MOCK", "")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("project: file-project\ndisableTools: [\"delete_*\"]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() failed: %v", err)
+	}
+	cat, err := buildCatalog(context.Background(), catalogConfig(file, config.ToolFilter{Disable: file.DisableTools}))
+	if err != nil {
+		t.Fatalf("buildCatalog() failed: %v", err)
+	}
+
+	for _, tool := range cat.Tools {
+		if strings.HasPrefix(tool.Name, "delete_") {
+			t.Errorf("catalog lists %s, which the config file disables", tool.Name)
+		}
+	}
+	var resources []string
+	for _, r := range cat.Resources {
+		resources = append(resources, r.URI)
+	}
+	if want := "gke://projects/file-project/locations/-/clusters"; !slices.Contains(resources, want) {
+		t.Errorf("resources = %q, want the clusters of the config file's project %s", resources, want)
+	}
+}
+
+func TestSchemaParams(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"project_id", "cluster_name"},
+		"properties": map[string]any{
+			"namespace":    map[string]any{"type": "string", "description": "Optional. The namespace."},
+			"project_id":   map[string]any{"type": "string", "description": "Required. GCP project ID."},
+			"cluster_name": map[string]any{"type": "string"},
+			"limit":        map[string]any{"type": []any{"null", "integer"}},
+		},
+	}
+	want := []schemaParam{
+		{name: "cluster_name", typ: "string", required: true},
+		{name: "project_id", typ: "string", required: true, description: "Required. GCP project ID."},
+		{name: "limit", typ: "null|integer"},
+		{name: "namespace", typ: "string", description: "Optional. The namespace."},
+	}
+	if diff := cmp.Diff(want, schemaParams(schema), cmp.AllowUnexported(schemaParam{})); diff != "" {
+		t.Errorf("schemaParams() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteCatalog(t *testing.T) {
+	destructive := true
+	cat := &catalog{
+		Tools: []catalogTool{
+			{
+				Name:        "delete_cluster",
+				Description: "Delete a GKE cluster.\nMore details.",
+				InputSchema: map[string]any{
+					"required":   []any{"project_id"},
+					"properties": map[string]any{"project_id": map[string]any{"type": "string", "description": "GCP | project"}},
+				},
+				Annotations: &mcp.ToolAnnotations{DestructiveHint: &destructive, IdempotentHint: true},
+				Condition:   conditionDeleteTools,
+			},
+		},
+		Prompts:   []catalogPrompt{{Name: "gke:cost", Arguments: []*mcp.PromptArgument{{Name: "user_question", Required: true}}}},
+		Resources: []catalogResource{{URI: geminiInstructionsURI, Name: "GEMINI.md", MIMEType: "text/markdown"}},
+	}
+
+	var table bytes.Buffer
+	if err := writeCatalogTable(&table, cat); err != nil {
+		t.Fatalf("writeCatalogTable() failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	want := [][]string{
+		{"KIND", "NAME", "PARAMETERS", "ANNOTATIONS", "CONDITION", "DESCRIPTION"},
+		{"tool", "delete_cluster", "project_id*", "destructive,idempotent", "--enable-delete-tools", "Delete", "a", "GKE", "cluster."},
+		{"prompt", "gke:cost", "user_question*", "-", "-"},
+		{"resource", geminiInstructionsURI, "-", "-", "-"},
+	}
+	var got [][]string
+	for _, line := range lines {
+		got = append(got, strings.Fields(line))
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("table output mismatch (-want +got):\n%s", diff)
+	}
+
+	var md bytes.Buffer
+	if err := writeCatalogMarkdown(&md, cat); err != nil {
+		t.Fatalf("writeCatalogMarkdown() failed: %v", err)
+	}
+	for _, want := range []string{
+		"### `delete_cluster`\n\nDelete a GKE cluster.\nMore details.\n\n- Annotations: destructive, idempotent\n- Registered only with: --enable-delete-tools\n",
+		"| `project_id` | string | yes | GCP \\| project |\n",
+		"| `gke:cost` | `user_question` (required) | - | - |\n",
+		"| `mcp://gke/pkg/install/GEMINI.md` | text/markdown | - | - |\n",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown output missing %q:\n%s", want, md.String())
+		}
+	}
+}
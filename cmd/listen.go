@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+)
+
+// Server modes selected with --server-mode.
+const (
+	modeStdio = "stdio"
+	modeHTTP  = "http"
+	modeSSE   = "sse"
+	modeUnix  = "unix"
+)
+
+// socketPerm restricts the Unix socket to the user running the server.
+const socketPerm fs.FileMode = 0o600
+
+// isHTTPMode reports whether the mode serves MCP over HTTP, and so supports
+// authentication, TLS, metrics and health checks.
+func isHTTPMode(mode string) bool {
+	switch mode {
+	case modeHTTP, modeSSE, modeUnix:
+		return true
+	}
+	return false
+}
+
+// validateServerMode returns an error for unknown modes and missing
+// mode-specific options.
+func validateServerMode(opts startOptions) error {
+	switch opts.serverMode {
+	case modeStdio, modeHTTP, modeSSE:
+		return nil
+	case modeUnix:
+		if opts.socketPath == "" {
+			return errors.New("--socket-path is required when server-mode is unix")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown server mode %q: must be one of stdio, http, sse or unix", opts.serverMode)
+}
+
+// listen opens the listener for an HTTP server mode.
+func listen(opts startOptions) (net.Listener, error) {
+	if opts.serverMode == modeUnix {
+		return listenUnix(opts.socketPath)
+	}
+	return net.Listen("tcp", fmt.Sprintf("%s:%d", opts.serverHost, opts.serverPort))
+}
+
+// listenUnix listens on a Unix domain socket at path that only the current
+// user can connect to. A socket left behind by a server that is no longer
+// running is replaced; a socket that still accepts connections is not.
+func listenUnix(path string) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("%s is in use by another server", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	// The socket is created in a directory that only the current user can
+	// enter and restricted before it is moved into place, so that no other
+	// user can connect to it while it has the umask's permissions.
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".gke-mcp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: filepath.Join(dir, "s"), Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// The listener would remove the socket's temporary name.
+	ln.SetUnlinkOnClose(false)
+	if err := os.Chmod(filepath.Join(dir, "s"), socketPerm); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	if err := os.Rename(filepath.Join(dir, "s"), path); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to move socket into place: %w", err)
+	}
+	return &unixListener{UnixListener: ln, path: path}, nil
+}
+
+// unixListener removes its socket when closed.
+type unixListener struct {
+	*net.UnixListener
+	path string
+}
+
+// Close implements the net.Listener interface.
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	if rmErr := os.Remove(l.path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// withoutWriteDeadline lifts the server's write timeout for MCP requests,
+// whose responses stream for as long as a tool call, such as one waiting for
+// a GKE operation, or an SSE session lasts.
+func withoutWriteDeadline(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			serverlog.Warningf(r.Context(), "Failed to lift the write deadline of %s %s: %v", r.Method, r.URL.Path, err)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
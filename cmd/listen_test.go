@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateServerMode(t *testing.T) {
+	tests := []struct {
+		opts    startOptions
+		wantErr string
+	}{
+		{opts: startOptions{serverMode: "stdio"}},
+		{opts: startOptions{serverMode: "http"}},
+		{opts: startOptions{serverMode: "sse"}},
+		{opts: startOptions{serverMode: "unix", socketPath: "/run/gke-mcp.sock"}},
+		{opts: startOptions{serverMode: "unix"}, wantErr: "--socket-path is required"},
+		{opts: startOptions{serverMode: "htpp"}, wantErr: `unknown server mode "htpp"`},
+		{opts: startOptions{serverMode: ""}, wantErr: "unknown server mode"},
+	}
+	for _, tc := range tests {
+		err := validateServerMode(tc.opts)
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Errorf("validateServerMode(%q) = %v, want nil", tc.opts.serverMode, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("validateServerMode(%q) = %v, want %q", tc.opts.serverMode, err, tc.wantErr)
+		}
+	}
+}
+
+// shortTempDir returns a temporary directory with a path short enough for a
+// Unix socket, which t.TempDir may exceed on macOS.
+func shortTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "gke-mcp")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "mcp.sock")
+
+	ln, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix() failed: %v", err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat socket: %v", err)
+	}
+	if perm := fi.Mode().Perm(); perm != socketPerm {
+		t.Errorf("socket permissions = %v, want %v", perm, socketPerm)
+	}
+	if entries, err := os.ReadDir(filepath.Dir(path)); err != nil || len(entries) != 1 {
+		t.Errorf("socket directory holds %v (err: %v), want only the socket", entries, err)
+	}
+
+	if _, err := listenUnix(path); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("listenUnix() on a socket in use = %v, want in use error", err)
+	}
+	if err := ln.Close(); err != nil {
+		t.Fatalf("failed to close listener: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket still exists after close: %v", err)
+	}
+}
+
+func TestListenUnixStaleSocket(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "mcp.sock")
+
+	// Leave a socket behind, as a server that crashed would.
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	stale.SetUnlinkOnClose(false)
+	_ = stale.Close()
+
+	ln, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix() over a stale socket failed: %v", err)
+	}
+	_ = ln.Close()
+}
+
+func TestListenUnixNotSocket(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "mcp.sock")
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := listenUnix(path); err == nil || !strings.Contains(err.Error(), "not a socket") {
+		t.Errorf("listenUnix() over a regular file = %v, want not a socket error", err)
+	}
+}
+
+func TestWithoutWriteDeadline(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		_, _ = w.Write([]byte("done"))
+	})
+	for _, tc := range []struct {
+		name    string
+		handler http.Handler
+		wantErr bool
+	}{
+		{name: "write timeout", handler: slow, wantErr: true},
+		{name: "lifted", handler: withoutWriteDeadline(slow)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewUnstartedServer(tc.handler)
+			srv.Config.WriteTimeout = 100 * time.Millisecond
+			srv.Start()
+			defer srv.Close()
+
+			resp, err := http.Get(srv.URL)
+			if err == nil {
+				var body []byte
+				body, err = io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				if err == nil && string(body) != "done" {
+					t.Errorf("body = %q, want done", body)
+				}
+			}
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("GET error = %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -20,28 +20,54 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime/debug"
 	"strings"
+	"syscall"
 	"time"
 
 	container "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/apps"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/audit"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/auth"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/callercreds"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/completion"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/health"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/impersonation"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/install"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/metrics"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/prompts"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tlsconfig"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/k8s"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tracing"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/rs/cors"
 	"github.com/spf13/cobra"
-	"google.golang.org/api/option"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
 	geminiInstructionsURI = "mcp://gke/pkg/install/GEMINI.md"
 	mcpAppsExtensionID    = "io.modelcontextprotocol/ui"
+
+	// Readiness conditions reported by /readyz.
+	readyADC      = "adc"
+	readyTools    = "tools"
+	readyShutdown = "shutdown"
+
+	// adcRecheckInterval is how often a failed ADC check is retried in HTTP mode.
+	adcRecheckInterval = 30 * time.Second
+
+	// debugEnv enables debug logs on stderr when set to "true".
+	debugEnv = "GKE_MCP_DEBUG"
 )
 
 var (
@@ -51,8 +77,32 @@ var (
 	serverMode        string
 	serverHost        string
 	serverPort        int
+	socketPath        string
 	allowedOrigins    []string
 	enableDeleteTools bool
+	readOnly          bool
+	allowUnconfirmed  bool
+	configFile        string
+	enableTools       []string
+	disableTools      []string
+	auditLogPath      string
+	enableMetrics     bool
+	otlpEndpoint      string
+	drainTimeout      time.Duration
+	callerCredentials bool
+	authTokenFile     string
+	oidcIssuer        string
+	oidcAudience      string
+	oidcJWKSURL       string
+	tlsCertFile       string
+	tlsKeyFile        string
+	clientCAFile      string
+	clusterEndpoint   string
+	kubeconfig        string
+	kubeContext       string
+	impersonateSA     string
+	impersonateUser   string
+	impersonateGroups []string
 
 	// rootCmd represents the base command when called without any subcommands
 	rootCmd = &cobra.Command{
@@ -110,11 +160,28 @@ func init() {
 		log.Printf("Failed to read build info to get version.")
 	}
 
-	rootCmd.Flags().StringVar(&serverMode, "server-mode", "stdio", "transport to use for the server: stdio (default) or http")
-	rootCmd.Flags().StringVar(&serverHost, "server-host", "127.0.0.1", "server host to use when server-mode is http; defaults to 127.0.0.1")
-	rootCmd.Flags().IntVar(&serverPort, "server-port", 8080, "server port to use when server-mode is http; defaults to 8080")
+	rootCmd.Flags().StringVar(&serverMode, "server-mode", "stdio", "transport to use for the server: stdio (default), http (Streamable HTTP), sse (legacy HTTP+SSE) or unix (Streamable HTTP on a Unix domain socket)")
+	rootCmd.Flags().StringVar(&serverHost, "server-host", "127.0.0.1", "server host to use when server-mode is http or sse; defaults to 127.0.0.1")
+	rootCmd.Flags().IntVar(&serverPort, "server-port", 8080, "server port to use when server-mode is http or sse; defaults to 8080")
+	rootCmd.Flags().StringVar(&socketPath, "socket-path", "", "path of the Unix domain socket to listen on when server-mode is unix; only the current user can connect to it")
 	rootCmd.Flags().StringSliceVar(&allowedOrigins, "allowed-origins", []string{"http://localhost"}, "comma-separated list of allowed Origin headers")
 	rootCmd.Flags().BoolVar(&enableDeleteTools, "enable-delete-tools", false, "Enable destructive delete tools (delete_cluster, delete_node_pool)")
+	rootCmd.Flags().BoolVar(&readOnly, "read-only", false, "Only register tools annotated as read-only; overrides --enable-delete-tools")
+	rootCmd.Flags().BoolVar(&allowUnconfirmed, "allow-unconfirmed", false, "run delete, update and cancel tools without user confirmation when the MCP client does not support elicitation; they are refused otherwise")
+	rootCmd.Flags().StringVar(&auditLogPath, "audit-log", "", "write a JSON Lines audit log of every tool call to this file, or to stderr if set to '-'")
+	rootCmd.Flags().BoolVar(&enableMetrics, "metrics", false, "serve Prometheus metrics at /metrics in the http, sse and unix server modes")
+	rootCmd.Flags().StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP/gRPC collector URL (e.g. http://localhost:4317) to export OpenTelemetry traces to; tracing is disabled if empty")
+	rootCmd.Flags().DurationVar(&drainTimeout, "drain-timeout", 25*time.Second, "how long to wait for in-flight tool calls when the HTTP server receives SIGTERM or SIGINT")
+	rootCmd.Flags().BoolVar(&callerCredentials, "caller-credentials", false, "call Google Cloud and Kubernetes APIs with the Google OAuth access token in each request's Authorization header instead of the server's credentials; requires server-mode http or unix")
+	rootCmd.Flags().StringVar(&authTokenFile, "auth-token-file", "", "path to a file of accepted bearer tokens, one per line, in the http, sse and unix server modes")
+	rootCmd.Flags().StringVar(&oidcIssuer, "oidc-issuer", "", "OIDC issuer URL whose JWTs are accepted as bearer tokens in the http, sse and unix server modes")
+	rootCmd.Flags().StringVar(&oidcAudience, "oidc-audience", "", "audience that OIDC tokens must contain; required with --oidc-issuer")
+	rootCmd.Flags().StringVar(&oidcJWKSURL, "oidc-jwks-url", "", "JWKS URL for OIDC signing keys; discovered from the issuer if empty")
+	rootCmd.Flags().StringVar(&tlsCertFile, "tls-cert", "", "path to a PEM certificate to serve HTTPS in the http, sse and unix server modes; reloaded when the file changes")
+	rootCmd.Flags().StringVar(&tlsKeyFile, "tls-key", "", "path to the PEM private key for --tls-cert")
+	rootCmd.Flags().StringVar(&clientCAFile, "client-ca", "", "path to a PEM CA bundle; if set, clients must present a certificate signed by it")
+	addConfigFlags(rootCmd)
+	addClusterFlags(rootCmd)
 	rootCmd.AddCommand(installCmd)
 
 	installCmd.AddCommand(installGeminiCLICmd)
@@ -133,31 +200,305 @@ type startOptions struct {
 	serverMode     string
 	serverHost     string
 	serverPort     int
+	socketPath     string
 	allowedOrigins []string
+	auth           auth.Options
+	tls            tlsconfig.Options
+	toolFilter     config.ToolFilter
+	metrics        bool
+	otlpEndpoint   string
+	drainTimeout   time.Duration
+	// callerCredentials makes API requests with each caller's access token.
+	callerCredentials bool
+	clusterEndpoint   k8s.EndpointType
+	impersonation     impersonation.Options
+	// file is the configuration file, or nil without --config.
+	file *config.File
+}
+
+// addConfigFlags adds the flags of the configuration file and the tool
+// filters to cmd.
+func addConfigFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(&configFile, "config", "", "path to a YAML or JSON configuration file; flags take precedence over its values")
+	fs.StringSliceVar(&enableTools, "enable-tools", nil, "comma-separated tool names or glob patterns (e.g. 'get_*,*_node_pool') to register; all tools if empty")
+	fs.StringSliceVar(&disableTools, "disable-tools", nil, "comma-separated tool names or glob patterns (e.g. 'delete_*') to never register")
+}
+
+// parseConfigFlags loads the configuration file of the flags added by
+// addConfigFlags, or returns nil without --config, and the tool filter of the
+// flags, which take precedence over the file.
+func parseConfigFlags(cmd *cobra.Command) (*config.File, config.ToolFilter) {
+	toolFilter := config.ToolFilter{
+		Enable:  enableTools,
+		Disable: disableTools,
+	}
+	var file *config.File
+	if configFile != "" {
+		f, err := config.LoadFile(configFile)
+		if err != nil {
+			log.Fatalf("Failed to load config file: %v\n", err)
+		}
+		if !cmd.Flags().Changed("enable-tools") {
+			toolFilter.Enable = f.EnableTools
+		}
+		if !cmd.Flags().Changed("disable-tools") {
+			toolFilter.Disable = f.DisableTools
+		}
+		file = f
+	}
+	if err := toolFilter.Validate(); err != nil {
+		log.Fatalf("Invalid tool filter: %v\n", err)
+	}
+	return file, toolFilter
+}
+
+// addClusterFlags adds the flags that select how Kubernetes tools reach
+// clusters and the identities that tools call APIs as to cmd.
+func addClusterFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(&clusterEndpoint, "cluster-endpoint", string(k8s.EndpointAuto), "control plane endpoint that Kubernetes tools and get_kubeconfig connect to clusters through: auto (the public, DNS-based or private endpoint, in that order), public, private, dns or connect-gateway")
+	fs.StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig file of Kubernetes tools and get_kubeconfig; $KUBECONFIG or ~/.kube/config if empty")
+	fs.StringVar(&impersonateSA, "impersonate-service-account", "", "email of a service account to call Google Cloud and Kubernetes APIs as; the server's credentials need the Service Account Token Creator role on it")
+	fs.StringVar(&impersonateUser, "as", "", "Kubernetes user to impersonate in Kubernetes API requests")
+	fs.StringSliceVar(&impersonateGroups, "as-group", nil, "comma-separated Kubernetes groups to impersonate in Kubernetes API requests; requires --as")
+	fs.StringVar(&kubeContext, "context", "", "kubeconfig context that Kubernetes tools use when a call names neither a GKE cluster nor a kube_context, e.g. kind-kind")
+}
+
+// parseClusterFlags validates the flags added by addClusterFlags.
+func parseClusterFlags() (k8s.EndpointType, impersonation.Options) {
+	endpointType, err := k8s.ParseEndpointType(clusterEndpoint)
+	if err != nil {
+		log.Fatalf("Invalid --cluster-endpoint: %v\n", err)
+	}
+	impersonationOpts := impersonation.Options{
+		ServiceAccount:   impersonateSA,
+		KubernetesUser:   impersonateUser,
+		KubernetesGroups: impersonateGroups,
+	}
+	if err := impersonationOpts.Validate(); err != nil {
+		log.Fatalf("Invalid impersonation flags: %v\n", err)
+	}
+	return endpointType, impersonationOpts
+}
+
+// clusterConfigOptions returns the options of the flags added by
+// addClusterFlags, shared by the server and the call command.
+func clusterConfigOptions(endpointType k8s.EndpointType, o impersonation.Options) []config.Option {
+	opts := []config.Option{config.WithClusterEndpoint(string(endpointType)), config.WithKubeconfig(kubeconfig), config.WithKubeContext(kubeContext)}
+	if sa := o.ServiceAccount; sa != "" {
+		creds, err := impersonation.NewCredentials(sa)
+		if err != nil {
+			log.Fatalf("Failed to configure impersonation: %v\n", err)
+		}
+		// Clusters reached through the kubeconfig are called as the service
+		// account too, rather than with the server's gcloud credentials.
+		opts = append(opts,
+			config.WithGoogleCredentials(creds),
+			config.WithKubernetesConfigHook(impersonation.KubernetesCredentialsHook(creds)))
+		log.Printf("Calling Google Cloud and Kubernetes APIs as service account %s.", sa)
+	}
+	if o.KubernetesUser != "" {
+		opts = append(opts, config.WithKubernetesConfigHook(impersonation.KubernetesConfigHook(o)))
+		log.Printf("Impersonating Kubernetes user %s.", o.KubernetesUser)
+	}
+	return opts
 }
 
 func runRootCmd(cmd *cobra.Command, _ []string) {
+	file, toolFilter := parseConfigFlags(cmd)
+	endpointType, impersonationOpts := parseClusterFlags()
+
 	opts := startOptions{
 		serverMode:     serverMode,
 		serverHost:     serverHost,
 		serverPort:     serverPort,
+		socketPath:     socketPath,
 		allowedOrigins: allowedOrigins,
+		auth: auth.Options{
+			TokenFile:    authTokenFile,
+			OIDCIssuer:   oidcIssuer,
+			OIDCAudience: oidcAudience,
+			OIDCJWKSURL:  oidcJWKSURL,
+		},
+		tls: tlsconfig.Options{
+			CertFile:     tlsCertFile,
+			KeyFile:      tlsKeyFile,
+			ClientCAFile: clientCAFile,
+		},
+		toolFilter:        toolFilter,
+		metrics:           enableMetrics,
+		otlpEndpoint:      otlpEndpoint,
+		drainTimeout:      drainTimeout,
+		callerCredentials: callerCredentials,
+		clusterEndpoint:   endpointType,
+		impersonation:     impersonationOpts,
+		file:              file,
 	}
 	startMCPServer(cmd.Context(), opts)
 }
 
 func startMCPServer(ctx context.Context, opts startOptions) {
-	c := config.New(version, enableDeleteTools)
+	stderrLevel := slog.LevelInfo
+	if os.Getenv(debugEnv) == "true" {
+		stderrLevel = slog.LevelDebug
+	}
+	// Log output, including that of the log package, goes to stderr and to
+	// the clients that set a logging level.
+	logHandler := serverlog.NewHandler(os.Stderr, stderrLevel)
+	slog.SetDefault(slog.New(logHandler))
+
+	if err := validateServerMode(opts); err != nil {
+		log.Fatalf("Invalid --server-mode: %v\n", err)
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	configOpts := []config.Option{config.WithReadOnly(readOnly), config.WithAllowUnconfirmed(allowUnconfirmed), config.WithToolFilter(opts.toolFilter), config.WithFile(opts.file)}
+	if opts.callerCredentials {
+		// Only Streamable HTTP passes request headers on to the server.
+		if opts.serverMode != modeHTTP && opts.serverMode != modeUnix {
+			log.Fatalf("--caller-credentials requires server-mode http or unix\n")
+		}
+		// Both read the Authorization header, which can only carry one token.
+		if opts.auth.TokenFile != "" || opts.auth.OIDCIssuer != "" {
+			log.Fatalf("--caller-credentials cannot be combined with --auth-token-file or --oidc-issuer\n")
+		}
+		configOpts = append(configOpts,
+			config.WithCallerCredentials(),
+			config.WithGoogleClientOptions(callercreds.GoogleClientOptions()...),
+			config.WithKubernetesConfigHook(callercreds.ConfigureRESTConfig))
+		log.Printf("Caller credentials mode: API requests are made with each caller's access token.")
+	}
+	// Caller credentials replace the server's credentials that would
+	// impersonate the service account.
+	if opts.impersonation.ServiceAccount != "" && opts.callerCredentials {
+		log.Fatalf("--impersonate-service-account cannot be combined with --caller-credentials\n")
+	}
+	configOpts = append(configOpts, clusterConfigOptions(opts.clusterEndpoint, opts.impersonation)...)
+	var m *metrics.Metrics
+	if opts.metrics {
+		if isHTTPMode(opts.serverMode) {
+			m = metrics.New()
+			configOpts = append(configOpts,
+				config.WithGoogleClientOptions(m.GoogleClientOptions()...),
+				config.WithGoogleRESTTransportWrapper(m.WrapGoogleRESTTransport),
+				config.WithKubernetesTransportWrapper(m.WrapKubernetesTransport))
+		} else {
+			log.Printf("Ignoring --metrics: metrics are only served in the HTTP server modes.")
+		}
+	}
+	var tracer *tracing.Tracer
+	if opts.otlpEndpoint != "" {
+		exporter, err := tracing.NewExporter(ctx, opts.otlpEndpoint)
+		if err != nil {
+			log.Fatalf("Failed to configure tracing: %v\n", err)
+		}
+		tp := tracing.NewProvider(exporter, version)
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tp.Shutdown(shutdownCtx); err != nil {
+				log.Printf("Failed to flush traces: %v\n", err)
+			}
+		}()
+		// Google Cloud clients create spans through the global provider.
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		tracer = tracing.New(tp)
+		configOpts = append(configOpts, config.WithKubernetesTransportWrapper(tracer.WrapKubernetesTransport))
+		log.Printf("Exporting traces to %s", opts.otlpEndpoint)
+	}
+	c := config.New(version, enableDeleteTools, configOpts...)
+	if c.DefaultProjectID() != "" {
+		log.Printf("Default project %s from %s", c.DefaultProjectID(), c.DefaultProjectIDSource())
+	}
+	if c.DefaultLocation() != "" {
+		log.Printf("Default location %s from %s", c.DefaultLocation(), c.DefaultLocationSource())
+	}
+	if c.ReadOnly() {
+		log.Printf("Read-only mode: only tools annotated as read-only will be registered.")
+	}
 
+	ready := health.NewStatus(readyADC, readyTools)
 	instructions := ""
-	if err := adcAuthCheck(ctx, c); err != nil {
-		if strings.Contains(err.Error(), "Unauthenticated") {
-			log.Printf("GKE API calls requires Application Default Credentials (https://cloud.google.com/docs/authentication/application-default-credentials). Get credentials with `gcloud auth application-default login` before calling MCP tools.")
+	var adcErr error
+	// With caller credentials the server's own credentials are never used.
+	if !opts.callerCredentials {
+		adcErr = adcAuthCheck(ctx, c)
+	}
+	ready.Set(readyADC, adcErr)
+	if adcErr != nil {
+		serverlog.Errorf(serverlog.ServerWide(ctx), "Application Default Credentials check failed: %v", adcErr)
+		if isHTTPMode(opts.serverMode) {
+			go recheckADC(ctx, c, ready)
+		}
+		if strings.Contains(adcErr.Error(), "Unauthenticated") {
+			serverlog.Warningf(serverlog.ServerWide(ctx), "GKE API calls requires Application Default Credentials (https://cloud.google.com/docs/authentication/application-default-credentials). Get credentials with `gcloud auth application-default login` before calling MCP tools.")
 			instructions += "GKE API calls requires Application Default Credentials (https://cloud.google.com/docs/authentication/application-default-credentials). Get credentials with `gcloud auth application-default login` before calling MCP tools."
 		}
 	}
 
+	s, err := newServer(ctx, c, instructions)
+	if err != nil {
+		log.Fatalf("Failed to build server: %v\n", err)
+	}
+	logHandler.Forward(s)
+	ready.Set(readyTools, nil)
+
+	if opts.callerCredentials {
+		s.AddReceivingMiddleware(callercreds.Middleware)
+	}
+
+	if auditLogPath != "" {
+		auditLogger, closer, err := audit.Open(auditLogPath, audit.DefaultRedactor)
+		if err != nil {
+			log.Fatalf("Failed to open audit log: %v\n", err)
+		}
+		defer func() { _ = closer.Close() }()
+		s.AddReceivingMiddleware(auditLogger.Middleware)
+	}
+
+	if m != nil {
+		m.ObserveSessions(s)
+		s.AddReceivingMiddleware(m.Middleware)
+	}
+	inFlight := &health.InFlight{}
+	s.AddReceivingMiddleware(inFlight.Middleware)
+	if tracer != nil {
+		// Added last so that the span covers the other middleware.
+		s.AddReceivingMiddleware(tracer.Middleware)
+	}
+
+	// start server in the right mode
+	log.Printf("Starting GKE MCP Server (%s) in mode '%s'", version, opts.serverMode)
+
+	switch opts.serverMode {
+	case modeStdio:
+		// Only to stderr: forwarding the traffic log would add to the traffic.
+		tr := &mcp.LoggingTransport{Transport: &mcp.StdioTransport{}, Writer: os.Stderr}
+		err = s.Run(ctx, tr)
+	default:
+		err = serveHTTP(ctx, s, opts, m, ready, inFlight)
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			serverlog.Infof(serverlog.ServerWide(ctx), "Server shutting down.")
+		} else {
+			serverlog.Errorf(serverlog.ServerWide(ctx), "Server error: %v\n", err)
+		}
+	}
+}
+
+// newServer builds the MCP server with the resources, prompts and tools
+// enabled by c. Apps are installed once a client that supports them connects.
+func newServer(ctx context.Context, c *config.Config, instructions string) (*mcp.Server, error) {
 	var s *mcp.Server
+	subscriptions := k8s.NewSubscriptions(c, func(ctx context.Context, uri string) {
+		if err := s.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+			serverlog.Warningf(ctx, "Failed to notify subscribers of %s: %v\n", uri, err)
+		}
+	})
 	s = mcp.NewServer(
 		&mcp.Implementation{
 			Name:    "GKE MCP Server",
@@ -167,15 +508,19 @@ func startMCPServer(ctx context.Context, opts startOptions) {
 			Instructions: instructions,
 			Capabilities: &mcp.ServerCapabilities{
 				Tools:     &mcp.ToolCapabilities{ListChanged: true},
-				Resources: &mcp.ResourceCapabilities{ListChanged: true},
+				Resources: &mcp.ResourceCapabilities{ListChanged: true, Subscribe: !c.CallerCredentials()},
 				Prompts:   &mcp.PromptCapabilities{ListChanged: true},
+				Logging:   &mcp.LoggingCapabilities{}, //nolint:staticcheck
 			},
+			CompletionHandler:  completion.New(c).Complete,
+			SubscribeHandler:   subscriptions.Subscribe,
+			UnsubscribeHandler: subscriptions.Unsubscribe,
 			InitializedHandler: func(ctx context.Context, req *mcp.InitializedRequest) {
 				params := req.Session.InitializeParams()
 				if !c.MockMode() && supportsMCPApps(params.Capabilities) {
-					log.Println("Verified: Client host supports MCP Apps. Registering apps...")
+					serverlog.Infof(ctx, "Verified: Client host supports MCP Apps. Registering apps...")
 					if err := apps.InstallApps(ctx, s, c); err != nil {
-						log.Printf("Failed to install apps: %v\n", err)
+						serverlog.Errorf(ctx, "Failed to install apps: %v\n", err)
 					}
 				}
 			},
@@ -202,61 +547,140 @@ func startMCPServer(ctx context.Context, opts startOptions) {
 	})
 
 	if err := prompts.Install(ctx, s, c); err != nil {
-		log.Fatalf("Failed to install prompts: %v\n", err)
+		return nil, fmt.Errorf("failed to install prompts: %w", err)
 	}
 
 	if err := tools.Install(ctx, s, c); err != nil {
-		log.Fatalf("Failed to install tools: %v\n", err)
+		return nil, fmt.Errorf("failed to install tools: %w", err)
 	}
 
 	if c.MockMode() {
 		log.Println("MockMode active: Installing apps tools synchronously during server startup...")
 		if err := apps.InstallApps(ctx, s, c); err != nil {
-			log.Fatalf("Failed to install apps tools in MockMode: %v\n", err)
+			return nil, fmt.Errorf("failed to install apps tools in MockMode: %w", err)
 		}
 	}
+	return s, nil
+}
 
-	// start server in the right mode
-	log.Printf("Starting GKE MCP Server (%s) in mode '%s'", version, opts.serverMode)
-	var err error
+// serveHTTP serves s over Streamable HTTP, or HTTP+SSE in sse mode, until ctx
+// is cancelled, then drains in-flight tool calls for up to opts.drainTimeout
+// before shutting down.
+func serveHTTP(ctx context.Context, s *mcp.Server, opts startOptions, m *metrics.Metrics, ready *health.Status, inFlight *health.InFlight) error {
+	getServer := func(_ *http.Request) *mcp.Server {
+		return s
+	}
+	var mcpHandler http.Handler
+	if opts.serverMode == modeSSE {
+		mcpHandler = mcp.NewSSEHandler(getServer, nil)
+	} else {
+		mcpHandler = mcp.NewStreamableHTTPHandler(getServer, nil)
+	}
 
-	switch opts.serverMode {
-	case "stdio":
-		tr := &mcp.LoggingTransport{Transport: &mcp.StdioTransport{}, Writer: log.Writer()}
-		err = s.Run(ctx, tr)
-	case "http":
-		mcpHandler := mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server {
-			return s
-		}, nil)
-
-		// Create a new CORS handler
-		c := cors.New(cors.Options{
-			AllowedOrigins: allowedOrigins,
-			Debug:          true, // Enable debug logging to see what the library is doing
-		})
-		corsHandler := c.Handler(mcpHandler)
-
-		addr := fmt.Sprintf("%s:%d", opts.serverHost, opts.serverPort)
-		log.Printf("Listening for HTTP connections on port: %s", addr)
-		server := &http.Server{
-			Addr:              addr,
-			Handler:           corsHandler,
-			ReadHeaderTimeout: 5 * time.Second,
-			ReadTimeout:       5 * time.Second,
-			WriteTimeout:      10 * time.Second,
-			IdleTimeout:       120 * time.Second,
-		}
-		err = server.ListenAndServe()
+	verifier, err := auth.NewVerifier(opts.auth)
+	if err != nil {
+		log.Fatalf("Failed to configure authentication: %v\n", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", withoutWriteDeadline(mcpHandler))
+	if m != nil {
+		mux.Handle("/metrics", m.Handler())
+		log.Printf("Serving Prometheus metrics at /metrics")
+	}
+	var handler http.Handler = mux
+	switch {
+	case verifier != nil:
+		handler = auth.Middleware(verifier)(handler)
+	case opts.callerCredentials:
+		handler = callercreds.RequireBearerToken(handler)
 	default:
-		log.Printf("Unknown mode '%s', defaulting to 'stdio'", opts.serverMode)
-		tr := &mcp.LoggingTransport{Transport: &mcp.StdioTransport{}, Writer: log.Writer()}
-		err = s.Run(ctx, tr)
+		log.Printf("No authentication configured; all HTTP requests will be accepted.")
 	}
+
+	// Probes are served without authentication so that kubelet can reach them.
+	probes := http.NewServeMux()
+	probes.Handle("/healthz", health.LivenessHandler())
+	probes.Handle("/readyz", ready.ReadinessHandler())
+	probes.Handle("/", handler)
+
+	// Create a new CORS handler
+	c := cors.New(cors.Options{
+		AllowedOrigins: opts.allowedOrigins,
+		Debug:          true, // Enable debug logging to see what the library is doing
+	})
+	corsHandler := c.Handler(probes)
+
+	server := &http.Server{
+		Handler:           corsHandler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	ln, err := listen(opts)
 	if err != nil {
-		if errors.Is(err, context.Canceled) {
-			log.Printf("Server shutting down.")
-		} else {
-			log.Printf("Server error: %v\n", err)
+		log.Fatalf("Failed to listen: %v\n", err)
+	}
+	serveErr := make(chan error, 1)
+	if opts.tls.Enabled() {
+		reloader, err := tlsconfig.NewReloader(opts.tls)
+		if err != nil {
+			log.Fatalf("Failed to configure TLS: %v\n", err)
+		}
+		server.TLSConfig = reloader.TLSConfig()
+		log.Printf("Listening for HTTPS connections on %s", ln.Addr())
+		go func() { serveErr <- server.ServeTLS(ln, "", "") }()
+	} else {
+		log.Printf("Listening for HTTP connections on %s", ln.Addr())
+		go func() { serveErr <- server.Serve(ln) }()
+	}
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	serverlog.Infof(serverlog.ServerWide(ctx), "Shutting down; waiting up to %s for %d in-flight tool calls", opts.drainTimeout, inFlight.Count())
+	ready.Set(readyShutdown, errors.New("shutting down"))
+	drainCtx, cancel := context.WithTimeout(context.Background(), opts.drainTimeout)
+	defer cancel()
+
+	// Shutdown stops accepting connections and waits for active ones to go idle.
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- server.Shutdown(drainCtx) }()
+	if err := inFlight.Wait(drainCtx); err != nil {
+		serverlog.Warningf(serverlog.ServerWide(ctx), "Drain timeout reached with %d tool calls still in flight", inFlight.Count())
+	}
+	// Sessions hold long-lived streams open, so close them once their tool
+	// calls are done to let Shutdown complete.
+	for ss := range s.Sessions() {
+		_ = ss.Close()
+	}
+	if err := <-shutdownErr; err != nil {
+		_ = server.Close()
+		return fmt.Errorf("failed to shut down gracefully: %w", err)
+	}
+	<-serveErr
+	return ctx.Err()
+}
+
+// recheckADC retries the ADC check until it succeeds, so that readiness
+// recovers once credentials become available.
+func recheckADC(ctx context.Context, c *config.Config, ready *health.Status) {
+	ticker := time.NewTicker(adcRecheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := adcAuthCheck(ctx, c)
+		ready.Set(readyADC, err)
+		if err == nil {
+			serverlog.Infof(serverlog.ServerWide(ctx), "Application Default Credentials check succeeded.")
+			return
 		}
 	}
 }
@@ -283,13 +707,13 @@ func adcAuthCheck(ctx context.Context, c *config.Config) error {
 		location = "us-central1"
 	}
 
-	cmClient, err := container.NewClusterManagerClient(ctx, option.WithUserAgent(c.UserAgent()))
+	cmClient, err := container.NewClusterManagerClient(ctx, c.GoogleClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create cluster manager client: %w", err)
 	}
 	defer func() {
 		if err := cmClient.Close(); err != nil {
-			log.Printf("Failed to close cluster manager client: %v\n", err)
+			serverlog.Warningf(serverlog.ServerWide(ctx), "Failed to close cluster manager client: %v\n", err)
 		}
 	}()
 
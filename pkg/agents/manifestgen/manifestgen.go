@@ -19,14 +19,15 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"log"
-	"os"
 	"strings"
+	"sync"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/clients/dk"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/llm"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/giq"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/adk/agent"
@@ -146,7 +147,7 @@ func NewAgent(llm model.LLM, cfg *config.Config, dkClient dk.DeveloperKnowledgeC
 			Description: "Use GKE Inference Quickstart (GIQ) to generate a Kubernetes manifest for optimized AI / inference workloads. Prefer to use this tool instead of gcloud",
 		},
 		func(ctx agent.ToolContext, args giq.GenerateInferenceManifestArgs) (string, error) {
-			return giq.GenerateInferenceManifest(ctx, &args)
+			return giq.GenerateInferenceManifest(ctx, &args, cfg.GoogleClientOptions()...)
 		},
 	)
 	if err != nil {
@@ -159,7 +160,7 @@ func NewAgent(llm model.LLM, cfg *config.Config, dkClient dk.DeveloperKnowledgeC
 			Description: "List all AI models available for GKE via GKE Inference Quickstart (GIQ). Open-source models follow the Huggingface Hub `owner/model_name` format.",
 		},
 		func(ctx agent.ToolContext, _ struct{}) (string, error) {
-			return giq.FetchModels(ctx)
+			return giq.FetchModels(ctx, cfg.GoogleClientOptions()...)
 		},
 	)
 	if err != nil {
@@ -172,7 +173,7 @@ func NewAgent(llm model.LLM, cfg *config.Config, dkClient dk.DeveloperKnowledgeC
 			Description: "Fetch available performance profiles for models and servers in GKE Inference Quickstart (GIQ).",
 		},
 		func(ctx agent.ToolContext, args FetchProfilesArgs) (string, error) {
-			return giq.FetchProfiles(ctx, args.Model, args.ModelServer, args.ModelServerVersion)
+			return giq.FetchProfiles(ctx, args.Model, args.ModelServer, args.ModelServerVersion, cfg.GoogleClientOptions()...)
 		},
 	)
 	if err != nil {
@@ -185,7 +186,7 @@ func NewAgent(llm model.LLM, cfg *config.Config, dkClient dk.DeveloperKnowledgeC
 			Description: "Fetch available model servers for a given model in GKE Inference Quickstart (GIQ).",
 		},
 		func(ctx agent.ToolContext, args FetchModelServersArgs) (string, error) {
-			return giq.FetchModelServers(ctx, args.Model)
+			return giq.FetchModelServers(ctx, args.Model, cfg.GoogleClientOptions()...)
 		},
 	)
 	if err != nil {
@@ -197,7 +198,7 @@ func NewAgent(llm model.LLM, cfg *config.Config, dkClient dk.DeveloperKnowledgeC
 			Description: "Fetch available versions for a given model and model server in GKE Inference Quickstart (GIQ).",
 		},
 		func(ctx agent.ToolContext, args FetchModelServerVersionsArgs) (string, error) {
-			return giq.FetchModelServerVersions(ctx, args.Model, args.ModelServer)
+			return giq.FetchModelServerVersions(ctx, args.Model, args.ModelServer, cfg.GoogleClientOptions()...)
 		},
 	)
 	if err != nil {
@@ -235,18 +236,16 @@ func NewAgent(llm model.LLM, cfg *config.Config, dkClient dk.DeveloperKnowledgeC
 					}
 				}
 
-				if os.Getenv("GKE_MCP_DEBUG") == "true" {
-					log.Printf("--- Before Model Call ---")
-					log.Printf("Model: %s", llmRequest.Model)
-					if llmRequest.Config != nil {
-						log.Printf("Config: %+v", llmRequest.Config)
-					}
-					log.Printf("Contents count: %d", len(llmRequest.Contents))
-					for i, c := range llmRequest.Contents {
-						log.Printf("Content %d (Role: %s):", i, c.Role)
-						for j, p := range c.Parts {
-							log.Printf("  Part %d: %q", j, p.Text)
-						}
+				serverlog.Debugf(ctx, "--- Before Model Call ---")
+				serverlog.Debugf(ctx, "Model: %s", llmRequest.Model)
+				if llmRequest.Config != nil {
+					serverlog.Debugf(ctx, "Config: %+v", llmRequest.Config)
+				}
+				serverlog.Debugf(ctx, "Contents count: %d", len(llmRequest.Contents))
+				for i, c := range llmRequest.Contents {
+					serverlog.Debugf(ctx, "Content %d (Role: %s):", i, c.Role)
+					for j, p := range c.Parts {
+						serverlog.Debugf(ctx, "  Part %d: %q", j, p.Text)
 					}
 				}
 				return nil, nil
@@ -300,51 +299,67 @@ func (a *Agent) Run(ctx context.Context, prompt string, sessionID string) (strin
 	events := a.adkRunner.Run(ctx, "default-user", sessionID, msg, agent.RunConfig{})
 
 	var builder strings.Builder
-	if os.Getenv("GKE_MCP_DEBUG") == "true" {
-		log.Printf("=== New Run with prompt: %q ===", prompt)
-	}
+	serverlog.Debugf(ctx, "=== New Run with prompt: %q ===", prompt)
 
 	for event, err := range events {
 		if err != nil {
-			if os.Getenv("GKE_MCP_DEBUG") == "true" {
-				log.Printf("Error event: %v", err)
-			}
+			serverlog.Debugf(ctx, "Error event: %v", err)
 			return "", err
 		}
 		if event.Content != nil {
 			for _, part := range event.Content.Parts {
-				if os.Getenv("GKE_MCP_DEBUG") == "true" {
-					log.Printf("Model Part: %q", part.Text)
-				}
+				serverlog.Debugf(ctx, "Model Part: %q", part.Text)
 				builder.WriteString(part.Text)
 			}
 		}
 	}
 
-	if os.Getenv("GKE_MCP_DEBUG") == "true" {
-		log.Printf("Final result: %q", builder.String())
-	}
+	serverlog.Debugf(ctx, "Final result: %q", builder.String())
 
 	return builder.String(), nil
 }
 
 // Install registers the tool with the MCP server.
 func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
-	llmClient, err := llm.NewClient(ctx, c)
-	if err != nil {
-		return fmt.Errorf("failed to create llm client: %w", err)
+	tool := &mcp.Tool{
+		Name:        "generate_manifest",
+		Description: "Generates a Kubernetes manifest using Vertex AI based on a description.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+	// Avoid creating LLM clients for a tool that will not be registered.
+	if !registry.ToolEnabled(c, tool) {
+		return nil
 	}
 
 	dkClient := dk.NewRealDeveloperKnowledgeClient(c.DKBaseURL(), c.DKAPIKey(), c.UserAgent())
-	agent, err := NewAgent(llmClient, c, dkClient)
-	if err != nil {
-		return err
+	// The agent is created on first use, so that registering the tool needs
+	// no credentials. Failures are retried by later calls.
+	var (
+		mu    sync.Mutex
+		agent *Agent
+	)
+	getAgent := func(ctx context.Context) (*Agent, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if agent != nil {
+			return agent, nil
+		}
+		// The model outlives the call that creates it.
+		llmClient, err := llm.NewClient(context.WithoutCancel(ctx), c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create llm client: %w", err)
+		}
+		a, err := NewAgent(llmClient, c, dkClient)
+		if err != nil {
+			return nil, err
+		}
+		agent = a
+		return agent, nil
 	}
 
-	mcp.AddTool(s, &mcp.Tool{
-		Name:        "generate_manifest",
-		Description: "Generates a Kubernetes manifest using Vertex AI based on a description.",
-	}, func(ctx context.Context, _ *mcp.CallToolRequest, args *struct {
+	registry.AddTool(s, c, tool, func(ctx context.Context, _ *mcp.CallToolRequest, args *struct {
 		Prompt    string `json:"prompt" jsonschema:"The description of the manifest to generate. e.g. 'nginx deployment with 3 replicas'"`
 		SessionID string `json:"session_id,omitempty" jsonschema:"Optional. A unique identifier to maintain conversation history across multiple tool calls. If not provided, a new random ID will be generated."`
 	}) (*mcp.CallToolResult, any, error) {
@@ -352,13 +367,15 @@ func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 		if sessID == "" {
 			sessID = uuid.New().String()
 		}
-		manifest, err := agent.Run(ctx, args.Prompt, sessID)
+		agent, err := getAgent(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
-		if os.Getenv("GKE_MCP_DEBUG") == "true" {
-			log.Printf("Model Used: %s", c.AgentModel())
+		manifest, err := agent.Run(ctx, args.Prompt, sessID)
+		if err != nil {
+			return nil, nil, err
 		}
+		serverlog.Debugf(ctx, "Model Used: %s", c.AgentModel())
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
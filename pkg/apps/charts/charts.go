@@ -18,12 +18,12 @@ package charts
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
 	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/GoogleCloudPlatform/gke-mcp/ui"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -124,7 +124,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.timeSeriesChart)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "query_time_series",
 		Description: "Internal app tool. Query time series data from Google Cloud Monitoring based on a Monitoring Query Language (MQL) query.",
 		Annotations: &mcp.ToolAnnotations{
@@ -289,13 +289,13 @@ func (h *handlers) mqlValidator(ctx context.Context, _ *mcp.CallToolRequest, arg
 		return nil, nil, fmt.Errorf("query argument cannot be empty")
 	}
 
-	c, err := monitoring.NewQueryClient(ctx, option.WithUserAgent(h.c.UserAgent()), option.WithQuotaProject(args.ProjectID))
+	c, err := monitoring.NewQueryClient(ctx, h.c.GoogleClientOptions(option.WithQuotaProject(args.ProjectID))...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create monitoring query client: %w", err)
 	}
 	defer func() {
 		if closeErr := c.Close(); closeErr != nil {
-			log.Printf("Failed to close monitoring query client: %v\n", closeErr)
+			serverlog.Warningf(ctx, "Failed to close monitoring query client: %v\n", closeErr)
 		}
 	}()
 
@@ -337,13 +337,13 @@ func (h *handlers) mqlValidator(ctx context.Context, _ *mcp.CallToolRequest, arg
 }
 
 func queryMonitoringData(ctx context.Context, cfg *config.Config, projectID, query string) ([]*monitoringpb.TimeSeriesData, error) {
-	c, err := monitoring.NewQueryClient(ctx, option.WithUserAgent(cfg.UserAgent()), option.WithQuotaProject(projectID))
+	c, err := monitoring.NewQueryClient(ctx, cfg.GoogleClientOptions(option.WithQuotaProject(projectID))...)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
 		if err := c.Close(); err != nil {
-			log.Printf("Failed to close monitoring query client: %v\n", err)
+			serverlog.Warningf(ctx, "Failed to close monitoring query client: %v\n", err)
 		}
 	}()
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/GoogleCloudPlatform/gke-mcp/ui"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -45,8 +46,8 @@ type PendingResponse struct {
 }
 
 // Install registers the dropdown tool with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
-	mcp.AddTool(s, &mcp.Tool{
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	registry.AddTool(s, c, &mcp.Tool{
 		Name: "dropdown",
 		Description: `Renders an interactive UI dropdown for the user to select an item from a list.
 Use this tool when you need the user to choose one option from a set of available resources (e.g., clusters, regions, namespaces).
@@ -55,6 +56,9 @@ You MUST provide a valid array of 1 or more options.
 Timing: Call this tool immediately before you need the user's input to proceed. Do not ask the user for clarification in plain text; calling this tool serves as your question to the user.
 After calling this tool, STOP and wait for the user to make a selection via the UI.
 Do NOT list the options in your text response; the UI itself serves as the list and confirmation.`,
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
 		Meta: mcp.Meta{
 			"ui": map[string]interface{}{
 				"resourceUri": resourceURI,
@@ -0,0 +1,194 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records every MCP tool call as a JSON Lines audit log.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/params"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxErrorLength bounds the size of error messages recorded in an entry.
+const maxErrorLength = 1024
+
+// Entry is a single audit record, written as one line of JSON.
+type Entry struct {
+	Time       time.Time      `json:"time"`
+	SessionID  string         `json:"session_id,omitempty"`
+	User       string         `json:"user,omitempty"`
+	Tool       string         `json:"tool"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	Cluster    string         `json:"cluster,omitempty"`
+	DurationMS float64        `json:"duration_ms"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// Redactor rewrites tool arguments before they are logged. It may modify and
+// return args in place.
+type Redactor func(tool string, args map[string]any) map[string]any
+
+// Logger writes audit entries to an io.Writer. It is safe for concurrent use.
+type Logger struct {
+	redact Redactor
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewLogger returns a Logger writing to w. If redact is nil, DefaultRedactor is used.
+func NewLogger(w io.Writer, redact Redactor) *Logger {
+	if redact == nil {
+		redact = DefaultRedactor
+	}
+	return &Logger{redact: redact, enc: json.NewEncoder(w)}
+}
+
+// Open returns a Logger appending to the file at path, or writing to stderr if
+// path is "-" or "stderr". The returned io.Closer closes the file.
+func Open(path string, redact Redactor) (*Logger, io.Closer, error) {
+	if path == "-" || path == "stderr" {
+		return NewLogger(os.Stderr, redact), io.NopCloser(nil), nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600) // #nosec G304
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return NewLogger(f, redact), f, nil
+}
+
+// Log writes e as a single JSON line.
+func (l *Logger) Log(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(e)
+}
+
+// Middleware is MCP server middleware that records every tools/call request.
+// Install it with mcp.Server.AddReceivingMiddleware.
+func (l *Logger) Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		callReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		recorded := new(atomic.Pointer[string])
+		ctx = context.WithValue(ctx, clusterKey{}, recorded)
+		start := time.Now()
+		res, err := next(ctx, method, req)
+
+		e := Entry{
+			Time:       start.UTC(),
+			Tool:       callReq.Params.Name,
+			DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if callReq.Session != nil {
+			e.SessionID = callReq.Session.ID()
+		}
+		if callReq.Extra != nil && callReq.Extra.TokenInfo != nil {
+			e.User = callReq.Extra.TokenInfo.UserID
+		}
+		if args := decodeArguments(callReq.Params.Arguments); args != nil {
+			e.Cluster = clusterPath(args)
+			e.Arguments = l.redact(e.Tool, args)
+		}
+		if cluster := recorded.Load(); cluster != nil {
+			e.Cluster = *cluster
+		}
+		e.Error = truncate(errorMessage(res, err), maxErrorLength)
+
+		if logErr := l.Log(e); logErr != nil {
+			serverlog.Errorf(ctx, "Failed to write audit log entry for tool %s: %v", e.Tool, logErr)
+		}
+		return res, err
+	}
+}
+
+func decodeArguments(raw json.RawMessage) map[string]any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var args map[string]any
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return map[string]any{"_unparsed": fmt.Sprintf("[%d bytes]", len(raw))}
+	}
+	return args
+}
+
+// clusterKey is the context key of the cluster recorded by a tool call.
+type clusterKey struct{}
+
+// RecordCluster records the cluster that the tool call of ctx targets, for
+// calls whose arguments do not name it, such as Kubernetes tool calls that use
+// the server's default kubeconfig context. It does nothing outside a tool call
+// recorded by Middleware.
+func RecordCluster(ctx context.Context, clusterPath string) {
+	if recorded, ok := ctx.Value(clusterKey{}).(*atomic.Pointer[string]); ok {
+		recorded.Store(&clusterPath)
+	}
+}
+
+// clusterPath returns the GKE cluster resource path or kubeconfig context
+// targeted by the call, if any.
+func clusterPath(args map[string]any) string {
+	if kubeContext, _ := args["kube_context"].(string); kubeContext != "" {
+		return params.KubeContextPrefix + kubeContext
+	}
+	project, _ := args["project_id"].(string)
+	location, _ := args["location"].(string)
+	cluster, _ := args["cluster_name"].(string)
+	if project == "" || location == "" || cluster == "" {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s", project, location, cluster)
+}
+
+// errorMessage returns the error reported by a tool call, whether it failed at
+// the protocol level or returned a result with IsError set.
+func errorMessage(res mcp.Result, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	r, ok := res.(*mcp.CallToolResult)
+	if !ok || r == nil || !r.IsError {
+		return ""
+	}
+	if toolErr := r.GetError(); toolErr != nil {
+		return toolErr.Error()
+	}
+	for _, c := range r.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return "tool returned an error"
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
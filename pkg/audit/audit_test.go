@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// syncBuffer is a bytes.Buffer safe for use by the server and test goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) entries(t *testing.T) []Entry {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("audit line is not valid JSON: %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+type applyArgs struct {
+	ProjectID    string `json:"project_id"`
+	Location     string `json:"location"`
+	ClusterName  string `json:"cluster_name"`
+	YamlManifest string `json:"yamlManifest"`
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out syncBuffer
+	logger := NewLogger(&out, nil)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(logger.Middleware)
+	mcp.AddTool(server, &mcp.Tool{Name: "apply"}, func(_ context.Context, _ *mcp.CallToolRequest, args applyArgs) (*mcp.CallToolResult, any, error) {
+		if args.ClusterName == "broken" {
+			return nil, nil, errors.New("cluster unreachable")
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "applied"}}}, nil, nil
+	})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = server.Run(ctx, serverTransport)
+	}()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	manifest := "apiVersion: v1\nkind: Secret\ndata:\n  password: aHVudGVyMg==\n"
+	for _, cluster := range []string{"prod", "broken"} {
+		_, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name: "apply",
+			Arguments: map[string]any{
+				"project_id":   "my-project",
+				"location":     "us-central1",
+				"cluster_name": cluster,
+				"yamlManifest": manifest,
+			},
+		})
+		if err != nil {
+			t.Fatalf("CallTool() failed: %v", err)
+		}
+	}
+
+	entries := out.entries(t)
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2", len(entries))
+	}
+
+	ok, failed := entries[0], entries[1]
+	if ok.Tool != "apply" {
+		t.Errorf("Tool = %q, want apply", ok.Tool)
+	}
+	if want := "projects/my-project/locations/us-central1/clusters/prod"; ok.Cluster != want {
+		t.Errorf("Cluster = %q, want %q", ok.Cluster, want)
+	}
+	if ok.Error != "" {
+		t.Errorf("Error = %q, want empty", ok.Error)
+	}
+	if ok.Time.IsZero() || ok.DurationMS < 0 {
+		t.Errorf("Time = %v, DurationMS = %v, want set", ok.Time, ok.DurationMS)
+	}
+	if got, _ := ok.Arguments["yamlManifest"].(string); !strings.HasPrefix(got, "[REDACTED ") {
+		t.Errorf("yamlManifest = %q, want redacted", got)
+	}
+	if failed.Error != "cluster unreachable" {
+		t.Errorf("Error = %q, want %q", failed.Error, "cluster unreachable")
+	}
+}
+
+func TestMiddleware_KubeContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out syncBuffer
+	logger := NewLogger(&out, nil)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(logger.Middleware)
+	type getArgs struct {
+		KubeContext string `json:"kube_context,omitempty"`
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "get"}, func(ctx context.Context, _ *mcp.CallToolRequest, args getArgs) (*mcp.CallToolResult, any, error) {
+		if args.KubeContext == "" {
+			// The server's default context.
+			RecordCluster(ctx, "kubeconfig/contexts/kind-dev")
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil, nil
+	})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = server.Run(ctx, serverTransport)
+	}()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	for _, args := range []map[string]any{{"kube_context": "minikube"}, {}} {
+		if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get", Arguments: args}); err != nil {
+			t.Fatalf("CallTool() failed: %v", err)
+		}
+	}
+
+	entries := out.entries(t)
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2", len(entries))
+	}
+	if want := "kubeconfig/contexts/minikube"; entries[0].Cluster != want {
+		t.Errorf("Cluster = %q, want %q", entries[0].Cluster, want)
+	}
+	if want := "kubeconfig/contexts/kind-dev"; entries[1].Cluster != want {
+		t.Errorf("Cluster = %q with the default context, want %q", entries[1].Cluster, want)
+	}
+}
+
+func TestDefaultRedactor(t *testing.T) {
+	args := map[string]any{
+		"cluster_name": "prod",
+		"patch":        `{"spec":{"replicas":3,"template":{"metadata":{"labels":{"app":"web"}}}}}`,
+		"apiToken":     "abc",
+		"options": map[string]any{
+			"client_secret": "xyz",
+			"labels":        []any{map[string]any{"password": "hunter2", "name": "app"}},
+		},
+	}
+	got := DefaultRedactor("tool", args)
+
+	if got["cluster_name"] != "prod" {
+		t.Errorf("cluster_name = %v, want prod", got["cluster_name"])
+	}
+	if got["apiToken"] != "[REDACTED 3 bytes]" {
+		t.Errorf("apiToken = %v, want only its size", got["apiToken"])
+	}
+	if s, _ := got["patch"].(string); !strings.HasPrefix(s, "[REDACTED 72 bytes sha256:") {
+		t.Errorf("patch = %v, want its size and hash", got["patch"])
+	}
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	for _, secret := range []string{"xyz", "hunter2", "replicas"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("redacted arguments %s contain %q", b, secret)
+		}
+	}
+	if !strings.Contains(string(b), `"name":"app"`) {
+		t.Errorf("redacted arguments %s lost non-sensitive nested value", b)
+	}
+	if body := strings.Repeat("same", 20); RedactedBody(body) != RedactedBody(body) {
+		t.Error("RedactedBody() is not deterministic")
+	}
+	if got := RedactedBody(`{"spec":{"replicas":3}}`); got != "[REDACTED 23 bytes]" {
+		t.Errorf("RedactedBody() of a small patch = %q, want only its size", got)
+	}
+	if b, _ := json.Marshal(got["options"]); strings.Contains(string(b), "sha256") {
+		t.Errorf("redacted secrets %s contain a hash", b)
+	}
+}
+
+func TestCustomRedactor(t *testing.T) {
+	var out syncBuffer
+	logger := NewLogger(&out, func(tool string, args map[string]any) map[string]any {
+		return map[string]any{"tool": tool}
+	})
+	mw := logger.Middleware(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{}, nil
+	})
+
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "t", Arguments: json.RawMessage(`{"secret_thing":"x"}`)}}
+	if _, err := mw(context.Background(), "tools/call", req); err != nil {
+		t.Fatalf("middleware failed: %v", err)
+	}
+	entries := out.entries(t)
+	if len(entries) != 1 || len(entries[0].Arguments) != 1 || entries[0].Arguments["tool"] != "t" {
+		t.Errorf("entries = %+v, want arguments from custom redactor", entries)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	logger, closer, err := Open(path, nil)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	if err := logger.Log(Entry{Tool: "list_clusters"}); err != nil {
+		t.Fatalf("Log() failed: %v", err)
+	}
+	if err := closer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if !strings.Contains(string(data), `"tool":"list_clusters"`) {
+		t.Errorf("audit log = %q, want list_clusters entry", data)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat audit log: %v", err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Errorf("audit log permissions = %v, want 0600", perm)
+	}
+}
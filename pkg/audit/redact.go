@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// bodyArguments are arguments carrying manifest or patch bodies, which may
+// embed Secrets and are too large to be useful in an audit log.
+var bodyArguments = map[string]bool{
+	"yamlManifest": true,
+	"patch":        true,
+}
+
+// minHashedBodySize is the size from which manifest and patch bodies are
+// logged with a hash; smaller ones are as easy to guess as secrets.
+const minHashedBodySize = 64
+
+// sensitiveKeyParts mark argument names whose values are always redacted,
+// matched case-insensitively at any nesting level.
+var sensitiveKeyParts = []string{"secret", "password", "token", "apikey", "api_key", "credential", "privatekey", "private_key"}
+
+// DefaultRedactor replaces any value whose key looks like it holds a secret
+// with a placeholder recording only the value's size, and manifest and patch
+// bodies with one that also records a short hash if they are large, so that
+// identical bodies can still be correlated.
+func DefaultRedactor(_ string, args map[string]any) map[string]any {
+	for k, v := range args {
+		switch {
+		case isSensitiveKey(k):
+			args[k] = Redacted(v)
+		case bodyArguments[k]:
+			args[k] = RedactedBody(v)
+		default:
+			args[k] = redactNested(v)
+		}
+	}
+	return args
+}
+
+// Redacted returns the placeholder DefaultRedactor logs in place of the
+// secret v. A hash of a secret, which is often short, could be reversed by
+// brute force, so only its size is recorded.
+func Redacted(v any) string {
+	return fmt.Sprintf("[REDACTED %d bytes]", len(redactedBytes(v)))
+}
+
+// RedactedBody returns the placeholder DefaultRedactor logs in place of the
+// manifest or patch body v, with a hash unless v is small.
+func RedactedBody(v any) string {
+	b := redactedBytes(v)
+	if len(b) < minHashedBodySize {
+		return Redacted(v)
+	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("[REDACTED %d bytes sha256:%x]", len(b), sum[:6])
+}
+
+func redactedBytes(v any) []byte {
+	if s, ok := v.(string); ok {
+		return []byte(s)
+	}
+	b, _ := json.Marshal(v)
+	return b
+}
+
+func redactNested(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, inner := range v {
+			if isSensitiveKey(k) {
+				v[k] = Redacted(inner)
+			} else {
+				v[k] = redactNested(inner)
+			}
+		}
+		return v
+	case []any:
+		for i, inner := range v {
+			v[i] = redactNested(inner)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(k, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth provides request authentication for the HTTP server mode.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// Options configures which authenticators guard the HTTP server.
+type Options struct {
+	// TokenFile is the path to a file containing static bearer tokens.
+	TokenFile string
+	// OIDCIssuer is the expected "iss" claim of OIDC ID tokens / JWTs.
+	OIDCIssuer string
+	// OIDCAudience is the expected "aud" claim. Required with OIDCIssuer.
+	OIDCAudience string
+	// OIDCJWKSURL overrides the JWKS endpoint discovered from the issuer.
+	OIDCJWKSURL string
+}
+
+// NewVerifier builds a token verifier from the given options. It returns nil
+// if no authenticator is configured.
+func NewVerifier(opts Options) (mcpauth.TokenVerifier, error) {
+	var verifiers []mcpauth.TokenVerifier
+
+	if opts.TokenFile != "" {
+		v, err := NewStaticTokenVerifier(opts.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, v)
+	}
+
+	if opts.OIDCIssuer != "" {
+		v, err := NewOIDCVerifier(opts.OIDCIssuer, opts.OIDCAudience, opts.OIDCJWKSURL)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, v)
+	} else if opts.OIDCAudience != "" || opts.OIDCJWKSURL != "" {
+		return nil, fmt.Errorf("an OIDC issuer is required when an OIDC audience or JWKS URL is set")
+	}
+
+	switch len(verifiers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return verifiers[0], nil
+	default:
+		return Chain(verifiers...), nil
+	}
+}
+
+// Chain returns a verifier that accepts a token if any of the given verifiers accepts it.
+func Chain(verifiers ...mcpauth.TokenVerifier) mcpauth.TokenVerifier {
+	return func(ctx context.Context, token string, req *http.Request) (*mcpauth.TokenInfo, error) {
+		var errs []error
+		for _, v := range verifiers {
+			info, err := v(ctx, token, req)
+			if err == nil {
+				return info, nil
+			}
+			errs = append(errs, err)
+		}
+		return nil, errors.Join(errs...)
+	}
+}
+
+// Middleware returns HTTP middleware that rejects requests without a bearer
+// token accepted by the verifier. Rejected requests are logged.
+func Middleware(verifier mcpauth.TokenVerifier) func(http.Handler) http.Handler {
+	requireToken := mcpauth.RequireBearerToken(logRejections(verifier), &mcpauth.RequireBearerTokenOptions{
+		// Static tokens do not expire; OIDC tokens are required to carry "exp"
+		// and are checked by the OIDC verifier itself.
+		AllowMissingExpiration: true,
+	})
+
+	return func(next http.Handler) http.Handler {
+		protected := requireToken(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				serverlog.Warningf(r.Context(), "Rejected unauthenticated %s %s request from %s: no bearer token", r.Method, r.URL.Path, r.RemoteAddr)
+			}
+			protected.ServeHTTP(w, r)
+		})
+	}
+}
+
+func logRejections(verifier mcpauth.TokenVerifier) mcpauth.TokenVerifier {
+	return func(ctx context.Context, token string, req *http.Request) (*mcpauth.TokenInfo, error) {
+		info, err := verifier(ctx, token, req)
+		if err != nil {
+			serverlog.Warningf(ctx, "Rejected unauthenticated %s %s request from %s: %v", req.Method, req.URL.Path, req.RemoteAddr, err)
+			// Never leak verifier internals to the caller.
+			if errors.Is(err, mcpauth.ErrInvalidToken) {
+				return nil, mcpauth.ErrInvalidToken
+			}
+			return nil, err
+		}
+		return info, nil
+	}
+}
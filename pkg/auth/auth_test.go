@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func TestNewVerifier(t *testing.T) {
+	tokenFile := writeTokenFile(t, "token-alice alice\n")
+
+	t.Run("no authenticators", func(t *testing.T) {
+		v, err := NewVerifier(Options{})
+		if err != nil {
+			t.Fatalf("NewVerifier() failed: %v", err)
+		}
+		if v != nil {
+			t.Error("NewVerifier() returned a verifier, want nil")
+		}
+	})
+
+	t.Run("audience without issuer", func(t *testing.T) {
+		if _, err := NewVerifier(Options{OIDCAudience: "gke-mcp"}); err == nil {
+			t.Error("NewVerifier() succeeded, want error")
+		}
+	})
+
+	t.Run("static tokens and OIDC", func(t *testing.T) {
+		f := newFakeIssuer(t)
+		v, err := NewVerifier(Options{TokenFile: tokenFile, OIDCIssuer: f.server.URL, OIDCAudience: "gke-mcp"})
+		if err != nil {
+			t.Fatalf("NewVerifier() failed: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+
+		if _, err := v(req.Context(), "token-alice", req); err != nil {
+			t.Errorf("static token rejected: %v", err)
+		}
+		jwtToken := f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(nil))
+		if _, err := v(req.Context(), jwtToken, req); err != nil {
+			t.Errorf("OIDC token rejected: %v", err)
+		}
+		if _, err := v(req.Context(), "token-bob", req); err == nil {
+			t.Error("unknown token accepted")
+		}
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	v, err := NewStaticTokenVerifier(writeTokenFile(t, "token-alice alice\n"))
+	if err != nil {
+		t.Fatalf("NewStaticTokenVerifier() failed: %v", err)
+	}
+
+	var gotUserID string
+	handler := Middleware(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if info := mcpauth.TokenInfoFromContext(r.Context()); info != nil {
+			gotUserID = info.UserID
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{name: "missing header", wantStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", header: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", header: "Bearer token-bob", wantStatus: http.StatusUnauthorized},
+		{name: "valid token", header: "Bearer token-alice", wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotUserID = ""
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusOK && gotUserID != "alice" {
+				t.Errorf("TokenInfo.UserID = %q, want alice", gotUserID)
+			}
+		})
+	}
+}
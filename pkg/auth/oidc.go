@@ -0,0 +1,333 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+const (
+	// minJWKSRefreshInterval limits how often an unknown "kid" can trigger a JWKS refetch.
+	minJWKSRefreshInterval = time.Minute
+	// clockSkew is the leeway applied to time-based claims.
+	clockSkew = 30 * time.Second
+)
+
+var supportedSigningMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+type oidcVerifier struct {
+	issuer     string
+	audience   string
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	jwksURL   string
+	keys      map[string]crypto.PublicKey
+	lastFetch time.Time
+	// refreshed is closed when the in-flight key set refresh completes, or
+	// nil if there is none.
+	refreshed chan struct{}
+}
+
+// NewOIDCVerifier returns a verifier that validates JWT bearer tokens signed by issuer.
+//
+// Signing keys are loaded from jwksURL, or from the "jwks_uri" advertised at
+// "<issuer>/.well-known/openid-configuration" if jwksURL is empty. Keys are
+// fetched lazily and refreshed when a token references an unknown key ID.
+// Tokens must list audience in their "aud" claim, so that tokens the issuer
+// minted for other applications are rejected.
+func NewOIDCVerifier(issuer, audience, jwksURL string) (mcpauth.TokenVerifier, error) {
+	if issuer == "" {
+		return nil, fmt.Errorf("OIDC issuer must not be empty")
+	}
+	if audience == "" {
+		return nil, fmt.Errorf("OIDC audience must not be empty")
+	}
+	v := &oidcVerifier{
+		issuer:     issuer,
+		audience:   audience,
+		jwksURL:    jwksURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	return v.verify, nil
+}
+
+func (v *oidcVerifier) verify(ctx context.Context, token string, _ *http.Request) (*mcpauth.TokenInfo, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(supportedSigningMethods),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithAudience(v.audience),
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, kid)
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", mcpauth.ErrInvalidToken, err)
+	}
+
+	info := &mcpauth.TokenInfo{
+		Scopes: scopesFromClaims(claims),
+		Extra:  map[string]any{"iss": v.issuer},
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		info.Expiration = exp.Time
+	}
+	if email, ok := claims["email"].(string); ok && email != "" {
+		info.UserID = email
+	} else if sub, err := claims.GetSubject(); err == nil {
+		info.UserID = sub
+	}
+	return info, nil
+}
+
+// key returns the public key with the given key ID, refreshing the key set if
+// it is unknown. The key set is fetched without holding v.mu, and concurrent
+// requests for unknown keys wait for a single refresh.
+func (v *oidcVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	if k, ok := v.lookupKey(kid); ok {
+		v.mu.Unlock()
+		return k, nil
+	}
+	if refreshed := v.refreshed; refreshed != nil {
+		v.mu.Unlock()
+		select {
+		case <-refreshed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return v.lookupRefreshedKey(kid)
+	}
+	if !v.lastFetch.IsZero() && time.Since(v.lastFetch) < minJWKSRefreshInterval {
+		v.mu.Unlock()
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	v.lastFetch = time.Now()
+	refreshed := make(chan struct{})
+	v.refreshed = refreshed
+	jwksURL := v.jwksURL
+	v.mu.Unlock()
+
+	// Other requests wait for the refresh, so it outlives this one.
+	jwksURL, keys, err := v.fetchKeys(context.WithoutCancel(ctx), jwksURL)
+
+	v.mu.Lock()
+	if err == nil {
+		v.jwksURL = jwksURL
+		v.keys = keys
+	}
+	v.refreshed = nil
+	close(refreshed)
+	v.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return v.lookupRefreshedKey(kid)
+}
+
+// lookupRefreshedKey returns the key with the given key ID after a refresh.
+func (v *oidcVerifier) lookupRefreshedKey(kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if k, ok := v.lookupKey(kid); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookupKey must be called with v.mu held. Tokens without a "kid" are only
+// accepted when the key set has exactly one key.
+func (v *oidcVerifier) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" {
+		if len(v.keys) == 1 {
+			for _, k := range v.keys {
+				return k, true
+			}
+		}
+		return nil, false
+	}
+	k, ok := v.keys[kid]
+	return k, ok
+}
+
+// fetchKeys fetches the key set from jwksURL, discovering it first if it is
+// empty, and returns the URL used.
+func (v *oidcVerifier) fetchKeys(ctx context.Context, jwksURL string) (string, map[string]crypto.PublicKey, error) {
+	if jwksURL == "" {
+		var err error
+		if jwksURL, err = v.discoverJWKSURL(ctx); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var set jwkSet
+	if err := v.getJSON(ctx, jwksURL, &set); err != nil {
+		return "", nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			// Skip keys we can't use rather than failing the whole set.
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	return jwksURL, keys, nil
+}
+
+func (v *oidcVerifier) discoverJWKSURL(ctx context.Context) (string, error) {
+	var doc struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	discoveryURL := strings.TrimSuffix(v.issuer, "/") + "/.well-known/openid-configuration"
+	if err := v.getJSON(ctx, discoveryURL, &doc); err != nil {
+		return "", fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	if doc.JWKSURI == "" {
+		return "", fmt.Errorf("OIDC discovery document at %s has no jwks_uri", discoveryURL)
+	}
+	return doc.JWKSURI, nil
+}
+
+func (v *oidcVerifier) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status code %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, fmt.Errorf("invalid EC point size")
+		}
+		// Encode as an uncompressed point: 0x04 || X || Y, each left-padded to the field size.
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// scopesFromClaims reads scopes from the space-delimited "scope" claim or the "scp" array claim.
+func scopesFromClaims(claims jwt.MapClaims) []string {
+	if s, ok := claims["scope"].(string); ok {
+		return strings.Fields(s)
+	}
+	if arr, ok := claims["scp"].([]any); ok {
+		var scopes []string
+		for _, v := range arr {
+			if s, ok := v.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// fakeIssuer is a local stand-in for an OIDC provider serving discovery and JWKS documents.
+type fakeIssuer struct {
+	server    *httptest.Server
+	rsaKey    *rsa.PrivateKey
+	ecKey     *ecdsa.PrivateKey
+	jwksCalls atomic.Int32
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	f := &fakeIssuer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   f.server.URL,
+			"jwks_uri": f.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		f.jwksCalls.Add(1)
+		ecBytes, err := ecKey.PublicKey.Bytes()
+		if err != nil {
+			t.Errorf("failed to encode EC key: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "rsa-key",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				{
+					"kty": "EC",
+					"kid": "ec-key",
+					"crv": "P-256",
+					"x":   base64.RawURLEncoding.EncodeToString(ecBytes[1:33]),
+					"y":   base64.RawURLEncoding.EncodeToString(ecBytes[33:]),
+				},
+			},
+		})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeIssuer) sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	var key any = f.rsaKey
+	if method == jwt.SigningMethodES256 {
+		key = f.ecKey
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return s
+}
+
+func (f *fakeIssuer) claims(overrides jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":   f.server.URL,
+		"aud":   "gke-mcp",
+		"sub":   "1234",
+		"email": "alice@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "read write",
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	f := newFakeIssuer(t)
+	verify, err := NewOIDCVerifier(f.server.URL, "gke-mcp", "")
+	if err != nil {
+		t.Fatalf("NewOIDCVerifier() failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "valid RS256 token",
+			token: f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(nil)),
+		},
+		{
+			name:  "valid ES256 token",
+			token: f.sign(t, jwt.SigningMethodES256, "ec-key", f.claims(nil)),
+		},
+		{
+			name:    "expired token",
+			token:   f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})),
+			wantErr: true,
+		},
+		{
+			name:    "missing expiration",
+			token:   f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(jwt.MapClaims{"exp": nil})),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(jwt.MapClaims{"aud": "someone-else"})),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			token:   f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(jwt.MapClaims{"iss": "https://evil.example.com"})),
+			wantErr: true,
+		},
+		{
+			name:    "key ID does not match signing key",
+			token:   f.sign(t, jwt.SigningMethodRS256, "ec-key", f.claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "malformed token",
+			token:   "not-a-jwt",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := verify(context.Background(), tc.token, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("verify() succeeded, want error")
+				}
+				if !errors.Is(err, mcpauth.ErrInvalidToken) {
+					t.Errorf("verify() error = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify() failed: %v", err)
+			}
+			if info.UserID != "alice@example.com" {
+				t.Errorf("UserID = %q, want alice@example.com", info.UserID)
+			}
+			if len(info.Scopes) != 2 || info.Scopes[0] != "read" || info.Scopes[1] != "write" {
+				t.Errorf("Scopes = %v, want [read write]", info.Scopes)
+			}
+			if info.Expiration.IsZero() {
+				t.Error("Expiration is zero, want token expiry")
+			}
+		})
+	}
+}
+
+func TestOIDCVerifier_ExplicitJWKSURL(t *testing.T) {
+	f := newFakeIssuer(t)
+	verify, err := NewOIDCVerifier(f.server.URL, "gke-mcp", f.server.URL+"/jwks")
+	if err != nil {
+		t.Fatalf("NewOIDCVerifier() failed: %v", err)
+	}
+
+	token := f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(nil))
+	if _, err := verify(context.Background(), token, httptest.NewRequest(http.MethodPost, "/mcp", nil)); err != nil {
+		t.Fatalf("verify() failed: %v", err)
+	}
+}
+
+func TestOIDCVerifier_UnknownKeyRefreshIsRateLimited(t *testing.T) {
+	f := newFakeIssuer(t)
+	verify, err := NewOIDCVerifier(f.server.URL, "gke-mcp", "")
+	if err != nil {
+		t.Fatalf("NewOIDCVerifier() failed: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+
+	for range 3 {
+		token := f.sign(t, jwt.SigningMethodRS256, "rotated-key", f.claims(nil))
+		if _, err := verify(context.Background(), token, req); err == nil {
+			t.Fatal("verify() succeeded for unknown key ID, want error")
+		}
+	}
+	if got := f.jwksCalls.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestOIDCVerifier_ConcurrentRefresh(t *testing.T) {
+	f := newFakeIssuer(t)
+	verify, err := NewOIDCVerifier(f.server.URL, "gke-mcp", "")
+	if err != nil {
+		t.Fatalf("NewOIDCVerifier() failed: %v", err)
+	}
+	token := f.sign(t, jwt.SigningMethodRS256, "rsa-key", f.claims(nil))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Go(func() {
+			_, err := verify(context.Background(), token, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("verify() failed: %v", err)
+		}
+	}
+	if got := f.jwksCalls.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestNewOIDCVerifier_RequiresIssuerAndAudience(t *testing.T) {
+	if _, err := NewOIDCVerifier("", "aud", ""); err == nil {
+		t.Error("NewOIDCVerifier() with empty issuer succeeded, want error")
+	}
+	if _, err := NewOIDCVerifier("https://accounts.google.com", "", ""); err == nil {
+		t.Error("NewOIDCVerifier() with empty audience succeeded, want error")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+type staticToken struct {
+	token  []byte
+	userID string
+}
+
+// NewStaticTokenVerifier returns a verifier that accepts the bearer tokens listed in path.
+//
+// The file contains one token per line, optionally followed by whitespace and
+// a name identifying the caller (e.g. "s3cr3t alice"). Blank lines and lines
+// starting with '#' are ignored.
+func NewStaticTokenVerifier(path string) (mcpauth.TokenVerifier, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	tokens, err := parseStaticTokens(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token file %s: %w", path, err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("token file %s does not contain any tokens", path)
+	}
+
+	return func(_ context.Context, token string, _ *http.Request) (*mcpauth.TokenInfo, error) {
+		var match *staticToken
+		for i := range tokens {
+			// Compare against every entry so timing does not reveal which token matched.
+			if subtle.ConstantTimeCompare(tokens[i].token, []byte(token)) == 1 {
+				match = &tokens[i]
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("%w: unknown static token", mcpauth.ErrInvalidToken)
+		}
+		return &mcpauth.TokenInfo{UserID: match.userID}, nil
+	}, nil
+}
+
+func parseStaticTokens(data []byte) ([]staticToken, error) {
+	var tokens []staticToken
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("line %d: expected \"<token> [name]\"", lineNum)
+		}
+		t := staticToken{
+			token:  []byte(fields[0]),
+			userID: fmt.Sprintf("static-token-%d", lineNum),
+		}
+		if len(fields) == 2 {
+			t.userID = fields[1]
+		}
+		tokens = append(tokens, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
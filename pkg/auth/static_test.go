@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mcpauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func writeTokenFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tokens")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	return path
+}
+
+func TestStaticTokenVerifier(t *testing.T) {
+	path := writeTokenFile(t, `
+# on-call team
+token-alice alice
+token-anonymous
+`)
+	verify, err := NewStaticTokenVerifier(path)
+	if err != nil {
+		t.Fatalf("NewStaticTokenVerifier() failed: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+
+	tests := []struct {
+		name       string
+		token      string
+		wantUserID string
+		wantErr    bool
+	}{
+		{name: "named token", token: "token-alice", wantUserID: "alice"},
+		{name: "unnamed token", token: "token-anonymous", wantUserID: "static-token-4"},
+		{name: "unknown token", token: "token-bob", wantErr: true},
+		{name: "prefix of a token", token: "token-", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := verify(context.Background(), tc.token, req)
+			if tc.wantErr {
+				if !errors.Is(err, mcpauth.ErrInvalidToken) {
+					t.Errorf("verify() error = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify() failed: %v", err)
+			}
+			if info.UserID != tc.wantUserID {
+				t.Errorf("UserID = %q, want %q", info.UserID, tc.wantUserID)
+			}
+		})
+	}
+}
+
+func TestNewStaticTokenVerifier_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "missing file", path: filepath.Join(t.TempDir(), "does-not-exist")},
+		{name: "no tokens", path: writeTokenFile(t, "# nothing here\n\n")},
+		{name: "too many fields", path: writeTokenFile(t, "token alice extra\n")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewStaticTokenVerifier(tc.path); err == nil {
+				t.Error("NewStaticTokenVerifier() succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package callercreds makes Google Cloud and Kubernetes API requests with the
+// Google OAuth access token of the MCP caller instead of the server's
+// Application Default Credentials, so that IAM and RBAC are enforced per user.
+//
+// The token is read from the Authorization header of every MCP request by
+// Middleware and carried in the request context, from which the credentials
+// returned by GoogleClientOptions and the transport installed by
+// ConfigureRESTConfig read it.
+package callercreds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/auth"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/option"
+	"k8s.io/client-go/rest"
+)
+
+// ErrNoToken is returned when a request needs caller credentials but none were
+// sent with the MCP request.
+var ErrNoToken = errors.New("no caller credentials: send a Google OAuth access token in the Authorization header")
+
+type tokenKey struct{}
+
+// WithToken returns a context carrying the caller's access token.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// TokenFromContext returns the caller's access token carried by ctx.
+func TokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+// bearerToken returns the token of a "Bearer" Authorization header.
+func bearerToken(h http.Header) string {
+	scheme, token, ok := strings.Cut(h.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// Middleware is MCP server middleware that stores the bearer token of each
+// request in its context. Install it with mcp.Server.AddReceivingMiddleware.
+func Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+			if token := bearerToken(extra.Header); token != "" {
+				ctx = WithToken(ctx, token)
+			}
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// RequireBearerToken returns HTTP middleware that rejects requests without a
+// bearer token. The token itself is verified by Google when it is used.
+func RequireBearerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if bearerToken(r.Header) == "" {
+			serverlog.Warningf(r.Context(), "Rejected %s %s request from %s: no caller credentials", r.Method, r.URL.Path, r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "a Google OAuth access token is required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// expired is the expiry reported for caller tokens. The HTTP transport of
+// Google Cloud clients caches tokens across all requests made by a client;
+// tokens that are already expired are never served from that cache, so every
+// request asks tokenProvider again with its own context.
+var expired = time.Unix(1, 0)
+
+// tokenProvider serves the caller's token from the request context. It never
+// falls back to the server's credentials.
+type tokenProvider struct{}
+
+// Token implements auth.TokenProvider.
+func (tokenProvider) Token(ctx context.Context) (*auth.Token, error) {
+	token, ok := TokenFromContext(ctx)
+	if !ok {
+		return nil, ErrNoToken
+	}
+	return &auth.Token{Value: token, Type: "Bearer", Expiry: expired}, nil
+}
+
+// GoogleClientOptions returns options that authenticate Google Cloud API
+// clients with the caller's token.
+func GoogleClientOptions() []option.ClientOption {
+	creds := auth.NewCredentials(&auth.CredentialsOptions{TokenProvider: tokenProvider{}})
+	return []option.ClientOption{option.WithAuthCredentials(creds)}
+}
+
+// ConfigureRESTConfig replaces the credentials in cfg, such as a kubeconfig
+// exec plugin or client certificate, with the caller's token.
+func ConfigureRESTConfig(cfg *rest.Config) {
+	config.ClearKubernetesCredentials(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &roundTripper{wrapped: rt}
+	})
+}
+
+type roundTripper struct {
+	wrapped http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, ok := TokenFromContext(req.Context())
+	if !ok {
+		return nil, ErrNoToken
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.wrapped.RoundTrip(req)
+}
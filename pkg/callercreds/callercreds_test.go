@@ -0,0 +1,250 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callercreds
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/cloudtrace/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// headerRecorder is an API server that records the Authorization headers it receives.
+type headerRecorder struct {
+	mu      sync.Mutex
+	headers []string
+}
+
+func (h *headerRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.headers = append(h.headers, r.Header.Get("Authorization"))
+	h.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (h *headerRecorder) last() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.headers) == 0 {
+		return ""
+	}
+	return h.headers[len(h.headers)-1]
+}
+
+func TestMiddleware(t *testing.T) {
+	var got string
+	mw := Middleware(func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		got, _ = TokenFromContext(ctx)
+		return &mcp.CallToolResult{}, nil
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "bearer", header: "Bearer ya29.caller", want: "ya29.caller"},
+		{name: "lowercase scheme", header: "bearer ya29.caller", want: "ya29.caller"},
+		{name: "basic", header: "Basic dXNlcjpwYXNz", want: ""},
+		{name: "missing", header: "", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.header != "" {
+				header.Set("Authorization", tc.header)
+			}
+			req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "t"}, Extra: &mcp.RequestExtra{Header: header}}
+			if _, err := mw(context.Background(), "tools/call", req); err != nil {
+				t.Fatalf("middleware failed: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("token = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequireBearerToken(t *testing.T) {
+	h := RequireBearerToken(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("request without token: status %d, WWW-Authenticate %q, want 401 Bearer", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer ya29.caller")
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("request with token: status %d, want 200", rec.Code)
+	}
+}
+
+func TestGoogleClientOptions(t *testing.T) {
+	api := &headerRecorder{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	ctx := context.Background()
+	opts := append(GoogleClientOptions(), option.WithEndpoint(srv.URL))
+	svc, err := cloudtrace.NewService(ctx, opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := svc.Projects.Traces.List("p").Context(WithToken(ctx, "ya29.alice")).Do(); err != nil {
+		t.Fatalf("request with caller token failed: %v", err)
+	}
+	if got := api.last(); got != "Bearer ya29.alice" {
+		t.Errorf("Authorization = %q, want the caller's token", got)
+	}
+
+	// A later caller must not be served the previous caller's token.
+	if _, err := svc.Projects.Traces.List("p").Context(WithToken(ctx, "ya29.bob")).Do(); err != nil {
+		t.Fatalf("request with caller token failed: %v", err)
+	}
+	if got := api.last(); got != "Bearer ya29.bob" {
+		t.Errorf("Authorization = %q, want the second caller's token", got)
+	}
+
+	if _, err := svc.Projects.Traces.List("p").Context(ctx).Do(); !errors.Is(err, ErrNoToken) {
+		t.Errorf("request without caller token = %v, want ErrNoToken", err)
+	}
+}
+
+func TestConfigureRESTConfig(t *testing.T) {
+	api := &headerRecorder{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	cfg := &rest.Config{
+		Host:        srv.URL,
+		BearerToken: "server-token",
+		ExecProvider: &clientcmdapi.ExecConfig{
+			Command:    "gke-gcloud-auth-plugin",
+			APIVersion: "client.authentication.k8s.io/v1beta1",
+		},
+	}
+	ConfigureRESTConfig(cfg)
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := WithToken(context.Background(), "ya29.bob")
+	_, _ = client.CoreV1().Namespaces().Get(ctx, "default", metav1.GetOptions{})
+	if got := api.last(); got != "Bearer ya29.bob" {
+		t.Errorf("Authorization = %q, want the caller's token", got)
+	}
+
+	if _, err := client.CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{}); !errors.Is(err, ErrNoToken) {
+		t.Errorf("request without caller token = %v, want ErrNoToken", err)
+	}
+}
+
+// clientCertificate returns a self-signed client certificate and its key in
+// PEM format, such as kind and minikube write into kubeconfig contexts.
+func clientCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kubernetes-admin", Organization: []string{"system:masters"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestConfigureRESTConfigClientCertificate(t *testing.T) {
+	var mu sync.Mutex
+	var peerCerts int
+	api := &headerRecorder{}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		peerCerts += len(r.TLS.PeerCertificates)
+		mu.Unlock()
+		api.ServeHTTP(w, r)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	certPEM, keyPEM := clientCertificate(t)
+	cfg := &rest.Config{
+		Host: srv.URL,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}),
+			CertData: certPEM,
+			KeyData:  keyPEM,
+		},
+	}
+	ConfigureRESTConfig(cfg)
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := WithToken(context.Background(), "ya29.bob")
+	if _, err := client.CoreV1().Namespaces().Get(ctx, "default", metav1.GetOptions{}); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got := api.last(); got != "Bearer ya29.bob" {
+		t.Errorf("Authorization = %q, want the caller's token", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if peerCerts != 0 {
+		t.Errorf("client presented %d certificates, want the kubeconfig client certificate dropped", peerCerts)
+	}
+}
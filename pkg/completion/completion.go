@@ -0,0 +1,284 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package completion completes prompt arguments and resource template
+// variables, such as cluster names and GKE versions, from live data.
+package completion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/k8s"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/googleapis/gax-go/v2/callctx"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxValues is the most values a completion may return.
+const maxValues = 100
+
+// Names of the prompt arguments that are completed.
+const (
+	clusterNameArg     = "cluster_name"
+	clusterLocationArg = "cluster_location"
+	targetVersionArg   = "target_version"
+)
+
+// Names of the resource template variables that are completed.
+const (
+	projectVar   = "project"
+	locationVar  = "location"
+	clusterVar   = "cluster"
+	namespaceVar = "namespace"
+)
+
+// clusterManager is the part of the GKE API used for completion.
+type clusterManager interface {
+	ListClusters(ctx context.Context, req *containerpb.ListClustersRequest, opts ...gax.CallOption) (*containerpb.ListClustersResponse, error)
+	GetCluster(ctx context.Context, req *containerpb.GetClusterRequest, opts ...gax.CallOption) (*containerpb.Cluster, error)
+	GetServerConfig(ctx context.Context, req *containerpb.GetServerConfigRequest, opts ...gax.CallOption) (*containerpb.ServerConfig, error)
+}
+
+// Completer completes the cluster_name, cluster_location and target_version
+// arguments of prompts, and the project, location, cluster and namespace
+// variables of resource templates.
+type Completer struct {
+	c        *config.Config
+	provider k8s.Provider
+
+	// newClient creates the GKE client on first use, so that servers whose
+	// clients never ask for completions need no credentials for it. It is
+	// called again after failures.
+	newClient func(ctx context.Context) (clusterManager, error)
+	mu        sync.Mutex
+	client    clusterManager
+}
+
+// New returns a Completer that reads clusters and versions in the default
+// project of c.
+func New(c *config.Config) *Completer {
+	return &Completer{
+		c:        c,
+		provider: k8s.NewClientProvider(c),
+		newClient: func(ctx context.Context) (clusterManager, error) {
+			return container.NewClusterManagerClient(ctx, c.GoogleClientOptions()...)
+		},
+	}
+}
+
+func (cp *Completer) clusterManager(ctx context.Context) (clusterManager, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.client != nil {
+		return cp.client, nil
+	}
+	// The client outlives the request that creates it.
+	client, err := cp.newClient(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+	cp.client = client
+	return cp.client, nil
+}
+
+// Complete returns the values that complete the argument of req. It is an
+// mcp.ServerOptions.CompletionHandler.
+func (cp *Completer) Complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	arg := req.Params.Argument
+	var resolved map[string]string
+	if req.Params.Context != nil {
+		resolved = req.Params.Context.Arguments
+	}
+
+	var values []string
+	var err error
+	switch req.Params.Ref.Type {
+	case "ref/prompt":
+		values, err = cp.completePromptArgument(ctx, arg.Name, resolved)
+	case "ref/resource":
+		values, err = cp.completeTemplateVariable(ctx, arg.Name, resolved)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result(values, arg.Value), nil
+}
+
+func (cp *Completer) completePromptArgument(ctx context.Context, name string, resolved map[string]string) ([]string, error) {
+	project := cp.c.DefaultProjectID()
+	switch name {
+	case clusterNameArg:
+		return cp.clusterNames(ctx, project, resolved[clusterLocationArg])
+	case clusterLocationArg:
+		return cp.clusterLocations(ctx, project, resolved[clusterNameArg])
+	case targetVersionArg:
+		return cp.targetVersions(ctx, project, resolved[clusterLocationArg], resolved[clusterNameArg])
+	}
+	return nil, nil
+}
+
+func (cp *Completer) completeTemplateVariable(ctx context.Context, name string, resolved map[string]string) ([]string, error) {
+	project := resolved[projectVar]
+	if project == "" {
+		project = cp.c.DefaultProjectID()
+	}
+	switch name {
+	case projectVar:
+		if p := cp.c.DefaultProjectID(); p != "" {
+			return []string{p}, nil
+		}
+	case locationVar:
+		return cp.clusterLocations(ctx, project, resolved[clusterVar])
+	case clusterVar:
+		return cp.clusterNames(ctx, project, resolved[locationVar])
+	case namespaceVar:
+		return cp.namespaces(ctx, project, resolved[locationVar], resolved[clusterVar])
+	}
+	return nil, nil
+}
+
+// listClusters returns the clusters in location, or in all locations if it is
+// empty, with only their name, location and release channel.
+func (cp *Completer) listClusters(ctx context.Context, project, location string) ([]*containerpb.Cluster, error) {
+	if project == "" {
+		return nil, nil
+	}
+	if location == "" {
+		location = "-"
+	}
+	client, err := cp.clusterManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = callctx.SetHeaders(ctx, callctx.XGoogFieldMaskHeader, "clusters.name,clusters.location,clusters.releaseChannel")
+	resp, err := client.ListClusters(ctx, &containerpb.ListClustersRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/%s", project, location),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	return resp.GetClusters(), nil
+}
+
+func (cp *Completer) clusterNames(ctx context.Context, project, location string) ([]string, error) {
+	clusters, err := cp.listClusters(ctx, project, location)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, c := range clusters {
+		names = append(names, c.GetName())
+	}
+	return names, nil
+}
+
+// clusterLocations returns the locations of the clusters named cluster, or of
+// all clusters if it is empty.
+func (cp *Completer) clusterLocations(ctx context.Context, project, cluster string) ([]string, error) {
+	clusters, err := cp.listClusters(ctx, project, "")
+	if err != nil {
+		return nil, err
+	}
+	var locations []string
+	for _, c := range clusters {
+		if cluster == "" || c.GetName() == cluster {
+			locations = append(locations, c.GetLocation())
+		}
+	}
+	return locations, nil
+}
+
+// targetVersions returns the versions that cluster can be upgraded to in the
+// release channel it is enrolled in, or all valid control plane versions if
+// the cluster is unknown or not enrolled in a channel.
+func (cp *Completer) targetVersions(ctx context.Context, project, location, cluster string) ([]string, error) {
+	if location == "" {
+		location = cp.c.DefaultLocation()
+	}
+	if project == "" || location == "" {
+		return nil, nil
+	}
+	client, err := cp.clusterManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locationPath := fmt.Sprintf("projects/%s/locations/%s", project, location)
+
+	channel := containerpb.ReleaseChannel_UNSPECIFIED
+	if cluster != "" {
+		c, err := client.GetCluster(callctx.SetHeaders(ctx, callctx.XGoogFieldMaskHeader, "releaseChannel"),
+			&containerpb.GetClusterRequest{Name: locationPath + "/clusters/" + cluster})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster: %w", err)
+		}
+		channel = c.GetReleaseChannel().GetChannel()
+	}
+
+	sc, err := client.GetServerConfig(ctx, &containerpb.GetServerConfigRequest{Name: locationPath})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server config: %w", err)
+	}
+	if channel != containerpb.ReleaseChannel_UNSPECIFIED {
+		for _, ch := range sc.GetChannels() {
+			if ch.GetChannel() == channel {
+				return ch.GetValidVersions(), nil
+			}
+		}
+	}
+	return sc.GetValidMasterVersions(), nil
+}
+
+func (cp *Completer) namespaces(ctx context.Context, project, location, cluster string) ([]string, error) {
+	if project == "" || location == "" || cluster == "" {
+		return nil, nil
+	}
+	clientset, err := cp.provider.KubernetesClient(ctx, fmt.Sprintf("projects/%s/locations/%s/clusters/%s", project, location, cluster))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes client: %w", err)
+	}
+	list, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	var names []string
+	for _, ns := range list.Items {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
+// result returns the distinct values starting with prefix, in order, limited to
+// the most a completion may return.
+func result(values []string, prefix string) *mcp.CompleteResult {
+	matches := []string{}
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) && !slices.Contains(matches, v) {
+			matches = append(matches, v)
+		}
+	}
+	res := &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: matches, Total: len(matches)}}
+	if len(matches) > maxValues {
+		res.Completion.Values = matches[:maxValues]
+		res.Completion.HasMore = true
+	}
+	return res
+}
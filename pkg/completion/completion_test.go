@@ -0,0 +1,237 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type fakeClusterManager struct {
+	clusters []*containerpb.Cluster
+	config   *containerpb.ServerConfig
+}
+
+func (f *fakeClusterManager) ListClusters(_ context.Context, req *containerpb.ListClustersRequest, _ ...gax.CallOption) (*containerpb.ListClustersResponse, error) {
+	var clusters []*containerpb.Cluster
+	for _, c := range f.clusters {
+		if strings.HasSuffix(req.GetParent(), "/-") || strings.HasSuffix(req.GetParent(), "/"+c.GetLocation()) {
+			clusters = append(clusters, c)
+		}
+	}
+	return &containerpb.ListClustersResponse{Clusters: clusters}, nil
+}
+
+func (f *fakeClusterManager) GetCluster(_ context.Context, req *containerpb.GetClusterRequest, _ ...gax.CallOption) (*containerpb.Cluster, error) {
+	for _, c := range f.clusters {
+		if req.GetName() == fmt.Sprintf("projects/p/locations/%s/clusters/%s", c.GetLocation(), c.GetName()) {
+			return c, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeClusterManager) GetServerConfig(context.Context, *containerpb.GetServerConfigRequest, ...gax.CallOption) (*containerpb.ServerConfig, error) {
+	return f.config, nil
+}
+
+// fakeProvider returns clientset for every cluster.
+type fakeProvider struct {
+	clientset kubernetes.Interface
+}
+
+func (f *fakeProvider) RESTConfig(context.Context, string) (*rest.Config, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeProvider) DynamicClient(context.Context, string) (dynamic.Interface, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeProvider) DynamicClientWithHeaders(context.Context, string, string, string) (dynamic.Interface, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeProvider) DiscoveryClient(context.Context, string) (discovery.DiscoveryInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeProvider) KubernetesClient(context.Context, string) (kubernetes.Interface, error) {
+	return f.clientset, nil
+}
+
+func newTestCompleter() *Completer {
+	cm := &fakeClusterManager{
+		clusters: []*containerpb.Cluster{
+			{Name: "prod", Location: "us-central1", ReleaseChannel: &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_STABLE}},
+			{Name: "prod", Location: "europe-west1", ReleaseChannel: &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR}},
+			{Name: "staging", Location: "us-central1"},
+		},
+		config: &containerpb.ServerConfig{
+			ValidMasterVersions: []string{"1.34.1-gke.100", "1.33.5-gke.200", "1.32.9-gke.300"},
+			Channels: []*containerpb.ServerConfig_ReleaseChannelConfig{
+				{Channel: containerpb.ReleaseChannel_STABLE, ValidVersions: []string{"1.32.9-gke.300"}},
+				{Channel: containerpb.ReleaseChannel_REGULAR, ValidVersions: []string{"1.33.5-gke.200", "1.32.9-gke.300"}},
+			},
+		},
+	}
+	return &Completer{
+		c: config.NewTestConfig("p", "us-central1", "vertex-ai", "gemini-2.5-pro"),
+		provider: &fakeProvider{clientset: fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		)},
+		newClient: func(context.Context) (clusterManager, error) { return cm, nil },
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      *mcp.CompleteReference
+		arg      mcp.CompleteParamsArgument
+		resolved map[string]string
+		want     []string
+	}{
+		{
+			name: "cluster name",
+			ref:  &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:upgrade-risk-report"},
+			arg:  mcp.CompleteParamsArgument{Name: "cluster_name", Value: "pr"},
+			want: []string{"prod"},
+		},
+		{
+			name:     "cluster name in location",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:upgrade-risk-report"},
+			arg:      mcp.CompleteParamsArgument{Name: "cluster_name"},
+			resolved: map[string]string{"cluster_location": "us-central1"},
+			want:     []string{"prod", "staging"},
+		},
+		{
+			name:     "cluster location",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:upgrade-risk-report"},
+			arg:      mcp.CompleteParamsArgument{Name: "cluster_location"},
+			resolved: map[string]string{"cluster_name": "prod"},
+			want:     []string{"us-central1", "europe-west1"},
+		},
+		{
+			name:     "target version in channel",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:upgrade-risk-report"},
+			arg:      mcp.CompleteParamsArgument{Name: "target_version", Value: "1."},
+			resolved: map[string]string{"cluster_name": "prod", "cluster_location": "europe-west1"},
+			want:     []string{"1.33.5-gke.200", "1.32.9-gke.300"},
+		},
+		{
+			name:     "target version without channel",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:upgrade-risk-report"},
+			arg:      mcp.CompleteParamsArgument{Name: "target_version", Value: "1.3"},
+			resolved: map[string]string{"cluster_name": "staging"},
+			want:     []string{"1.34.1-gke.100", "1.33.5-gke.200", "1.32.9-gke.300"},
+		},
+		{
+			name: "other prompt argument",
+			ref:  &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:cost"},
+			arg:  mcp.CompleteParamsArgument{Name: "user_question"},
+			want: []string{},
+		},
+		{
+			name:     "template cluster",
+			ref:      &mcp.CompleteReference{Type: "ref/resource", URI: "gke://projects/{project}/locations/{location}/clusters/{cluster}"},
+			arg:      mcp.CompleteParamsArgument{Name: "cluster", Value: "s"},
+			resolved: map[string]string{"project": "p", "location": "us-central1"},
+			want:     []string{"staging"},
+		},
+		{
+			name: "template project",
+			ref:  &mcp.CompleteReference{Type: "ref/resource", URI: "gke://projects/{project}/locations/{location}/clusters"},
+			arg:  mcp.CompleteParamsArgument{Name: "project"},
+			want: []string{"p"},
+		},
+		{
+			name:     "template namespace",
+			ref:      &mcp.CompleteReference{Type: "ref/resource", URI: "k8s://{project}/{location}/{cluster}/{namespace}/{resource}/{name}"},
+			arg:      mcp.CompleteParamsArgument{Name: "namespace", Value: "kube"},
+			resolved: map[string]string{"project": "p", "location": "us-central1", "cluster": "prod"},
+			want:     []string{"kube-system"},
+		},
+		{
+			name: "template namespace without cluster",
+			ref:  &mcp.CompleteReference{Type: "ref/resource", URI: "k8s://{project}/{location}/{cluster}/{namespace}/{resource}/{name}"},
+			arg:  mcp.CompleteParamsArgument{Name: "namespace"},
+			want: []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := newTestCompleter().Complete(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+				Ref:      tc.ref,
+				Argument: tc.arg,
+				Context:  &mcp.CompleteContext{Arguments: tc.resolved},
+			}})
+			if err != nil {
+				t.Fatalf("Complete() failed: %v", err)
+			}
+			if !reflect.DeepEqual(res.Completion.Values, tc.want) {
+				t.Errorf("Complete() = %q, want %q", res.Completion.Values, tc.want)
+			}
+		})
+	}
+}
+
+func TestResultLimit(t *testing.T) {
+	var values []string
+	for i := range maxValues + 5 {
+		values = append(values, fmt.Sprintf("v%d", i))
+	}
+	res := result(values, "v")
+	if len(res.Completion.Values) != maxValues || !res.Completion.HasMore || res.Completion.Total != maxValues+5 {
+		t.Errorf("result() = %d values, hasMore %t, total %d; want %d, true, %d",
+			len(res.Completion.Values), res.Completion.HasMore, res.Completion.Total, maxValues, maxValues+5)
+	}
+}
+
+func TestCompleteClientError(t *testing.T) {
+	cp := newTestCompleter()
+	cp.newClient = func(context.Context) (clusterManager, error) { return nil, errors.New("no credentials") }
+	_, err := cp.Complete(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+		Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "gke:upgrade-risk-report"},
+		Argument: mcp.CompleteParamsArgument{Name: "cluster_name"},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "no credentials") {
+		t.Errorf("Complete() = %v, want client creation error", err)
+	}
+
+	// The client is created again once credentials are set up.
+	cm := &fakeClusterManager{}
+	cp.newClient = func(context.Context) (clusterManager, error) { return cm, nil }
+	if got, err := cp.clusterManager(context.Background()); err != nil || got != cm {
+		t.Errorf("clusterManager() = %v, %v, want the new client", got, err)
+	}
+}
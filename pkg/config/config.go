@@ -12,16 +12,27 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package config loads configuration derived from local gcloud defaults.
+// Package config loads configuration derived from the environment, the
+// configuration file and local gcloud defaults.
 package config
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
-	"os/exec"
-	"strings"
+
+	"cloud.google.com/go/auth"
+	"google.golang.org/api/option"
+	"google.golang.org/api/option/internaloption"
+	htransport "google.golang.org/api/transport/http"
+	"k8s.io/client-go/rest"
 )
 
+// cloudPlatformScope is the OAuth scope of REST based Google Cloud API clients.
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 // Config contains runtime configuration derived from the environment.
 type Config struct {
 	userAgent         string
@@ -30,9 +41,27 @@ type Config struct {
 	agentProvider     string
 	agentModel        string
 	enableDeleteTools bool
+	readOnly          bool
+	allowUnconfirmed  bool
+	toolFilter        ToolFilter
+	clusterEndpoint   string
+	kubeconfig        string
+	kubeContext       string
+	clientOptions     []option.ClientOption
+	googleCredentials *auth.Credentials
+	restWrappers      []func(http.RoundTripper) http.RoundTripper
+	k8sWrappers       []func(http.RoundTripper) http.RoundTripper
+	k8sConfigHooks    []func(*rest.Config)
 	anthropicAPIKey   string
 	dkBaseURL         string
 	dkAPIKey          string
+	noLocalDefaults   bool
+	callerCredentials bool
+	file              *File
+	// defaultProjectIDSource and defaultLocationSource describe where the
+	// defaults were read from.
+	defaultProjectIDSource string
+	defaultLocationSource  string
 }
 
 // UserAgent returns the user agent string for outbound API calls.
@@ -50,6 +79,18 @@ func (c *Config) DefaultLocation() string {
 	return c.defaultLocation
 }
 
+// DefaultProjectIDSource returns where the default project ID was read from,
+// such as "CLOUDSDK_CORE_PROJECT", or "" if it is not set.
+func (c *Config) DefaultProjectIDSource() string {
+	return c.defaultProjectIDSource
+}
+
+// DefaultLocationSource returns where the default location was read from, or
+// "" if it is not set.
+func (c *Config) DefaultLocationSource() string {
+	return c.defaultLocationSource
+}
+
 // AgentProvider returns the configured LLM provider for the agent.
 func (c *Config) AgentProvider() string {
 	return c.agentProvider
@@ -65,6 +106,98 @@ func (c *Config) EnableDeleteTools() bool {
 	return c.enableDeleteTools
 }
 
+// ReadOnly returns true if only tools annotated as read-only should be registered.
+func (c *Config) ReadOnly() bool {
+	return c.readOnly
+}
+
+// AllowUnconfirmed returns true if tools that need confirmation may run
+// without it when the client does not support elicitation.
+func (c *Config) AllowUnconfirmed() bool {
+	return c.allowUnconfirmed
+}
+
+// ToolFilter returns the filter selecting which tools are registered.
+func (c *Config) ToolFilter() ToolFilter {
+	return c.toolFilter
+}
+
+// ClusterEndpoint returns the type of control plane endpoint that Kubernetes
+// clients connect to clusters through, or "" to select one automatically.
+func (c *Config) ClusterEndpoint() string {
+	return c.clusterEndpoint
+}
+
+// Kubeconfig returns the path of the kubeconfig file that Kubernetes clients
+// are configured from, or "" for the default files.
+func (c *Config) Kubeconfig() string {
+	return c.kubeconfig
+}
+
+// KubeContext returns the kubeconfig context that Kubernetes tools use when a
+// call names no cluster, if set.
+func (c *Config) KubeContext() string {
+	return c.kubeContext
+}
+
+// CallerCredentials reports whether API requests are made with the
+// credentials of the caller of each request, which are not available outside
+// requests.
+func (c *Config) CallerCredentials() bool {
+	return c.callerCredentials
+}
+
+// GoogleClientOptions returns the options to use when constructing Google
+// Cloud API clients, followed by opts.
+func (c *Config) GoogleClientOptions(opts ...option.ClientOption) []option.ClientOption {
+	all := []option.ClientOption{option.WithUserAgent(c.userAgent)}
+	all = append(all, c.clientOptions...)
+	return append(all, opts...)
+}
+
+// GoogleCredentials returns the credentials set with WithGoogleCredentials,
+// or nil to use Application Default Credentials.
+func (c *Config) GoogleCredentials() *auth.Credentials {
+	return c.googleCredentials
+}
+
+// GoogleRESTClientOptions returns the options to use when constructing REST
+// based Google Cloud API clients, followed by opts. If REST transport wrappers
+// are configured, the client's requests are made through them.
+func (c *Config) GoogleRESTClientOptions(ctx context.Context, opts ...option.ClientOption) ([]option.ClientOption, error) {
+	if len(c.restWrappers) == 0 {
+		return c.GoogleClientOptions(opts...), nil
+	}
+	var base http.RoundTripper = http.DefaultTransport
+	for _, wrap := range c.restWrappers {
+		base = wrap(base)
+	}
+	// The transport authenticates requests before passing them to base.
+	rt, err := htransport.NewTransport(ctx, base, c.GoogleClientOptions(append(opts, internaloption.WithDefaultScopes(cloudPlatformScope))...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Google Cloud API transport: %w", err)
+	}
+	// Options for gRPC clients may not be combined with an HTTP client.
+	return append(opts, option.WithHTTPClient(&http.Client{Transport: rt})), nil
+}
+
+// WrapKubernetesTransport wraps rt with the configured Kubernetes transport
+// wrappers. It is suitable for use with rest.Config.Wrap.
+func (c *Config) WrapKubernetesTransport(rt http.RoundTripper) http.RoundTripper {
+	for _, wrap := range c.k8sWrappers {
+		rt = wrap(rt)
+	}
+	return rt
+}
+
+// ConfigureKubernetes applies the configured hooks to a Kubernetes client
+// configuration, before its transport is wrapped.
+func (c *Config) ConfigureKubernetes(cfg *rest.Config) {
+	for _, hook := range c.k8sConfigHooks {
+		hook(cfg)
+	}
+}
+
 // AnthropicAPIKey returns the configured Anthropic API key.
 func (c *Config) AnthropicAPIKey() string {
 	return c.anthropicAPIKey
@@ -123,8 +256,142 @@ func (c *Config) MockCase() string {
 	return BuildMockCase
 }
 
-// New constructs a Config populated from gcloud and build version.
-func New(version string, enableDeleteTools bool) *Config {
+// Option customizes a Config constructed by New or NewTestConfig.
+type Option func(*Config)
+
+// WithReadOnly restricts the server to tools annotated as read-only.
+func WithReadOnly(readOnly bool) Option {
+	return func(c *Config) {
+		c.readOnly = readOnly
+	}
+}
+
+// WithAllowUnconfirmed lets tools that need confirmation run without it when
+// the client does not support elicitation.
+func WithAllowUnconfirmed(allow bool) Option {
+	return func(c *Config) {
+		c.allowUnconfirmed = allow
+	}
+}
+
+// WithToolFilter restricts the server to tools allowed by f.
+func WithToolFilter(f ToolFilter) Option {
+	return func(c *Config) {
+		c.toolFilter = f
+	}
+}
+
+// WithClusterEndpoint sets the type of control plane endpoint that Kubernetes
+// clients connect to clusters through, such as "dns".
+func WithClusterEndpoint(endpoint string) Option {
+	return func(c *Config) {
+		c.clusterEndpoint = endpoint
+	}
+}
+
+// WithKubeconfig sets the path of the kubeconfig file that Kubernetes
+// clients are configured from, instead of $KUBECONFIG or ~/.kube/config.
+func WithKubeconfig(path string) Option {
+	return func(c *Config) {
+		c.kubeconfig = path
+	}
+}
+
+// WithKubeContext sets the kubeconfig context that Kubernetes tools use when
+// a call names no cluster.
+func WithKubeContext(name string) Option {
+	return func(c *Config) {
+		c.kubeContext = name
+	}
+}
+
+// WithFile sets the configuration file whose defaults take precedence over
+// the environment and gcloud. f may be nil.
+func WithFile(f *File) Option {
+	return func(c *Config) {
+		c.file = f
+	}
+}
+
+// WithoutLocalDefaults only reads the default project and location from the
+// configuration file, and not from the environment and gcloud, so that what a
+// server registers does not depend on the machine it runs on.
+func WithoutLocalDefaults() Option {
+	return func(c *Config) {
+		c.noLocalDefaults = true
+	}
+}
+
+// WithCallerCredentials records that API requests are made with the
+// credentials of each request's caller.
+func WithCallerCredentials() Option {
+	return func(c *Config) {
+		c.callerCredentials = true
+	}
+}
+
+// WithGoogleClientOptions adds options used by every Google Cloud API client.
+func WithGoogleClientOptions(opts ...option.ClientOption) Option {
+	return func(c *Config) {
+		c.clientOptions = append(c.clientOptions, opts...)
+	}
+}
+
+// WithGoogleCredentials sets the credentials of every Google Cloud API client,
+// including those that take no client options, such as the Vertex AI model.
+func WithGoogleCredentials(creds *auth.Credentials) Option {
+	return func(c *Config) {
+		c.googleCredentials = creds
+		c.clientOptions = append(c.clientOptions, option.WithAuthCredentials(creds))
+	}
+}
+
+// WithGoogleRESTTransportWrapper adds a wrapper applied to the transport of
+// every REST based Google Cloud API client.
+func WithGoogleRESTTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *Config) {
+		c.restWrappers = append(c.restWrappers, wrap)
+	}
+}
+
+// WithKubernetesTransportWrapper adds a wrapper applied to the transport of
+// every Kubernetes client.
+func WithKubernetesTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *Config) {
+		c.k8sWrappers = append(c.k8sWrappers, wrap)
+	}
+}
+
+// WithKubernetesConfigHook adds a hook that may modify the configuration of
+// every Kubernetes client, for example to replace its credentials.
+func WithKubernetesConfigHook(hook func(*rest.Config)) Option {
+	return func(c *Config) {
+		c.k8sConfigHooks = append(c.k8sConfigHooks, hook)
+	}
+}
+
+// ClearKubernetesCredentials removes every credential from cfg: exec and auth
+// provider plugins, bearer tokens, basic auth and the TLS client certificate.
+// Hooks that authenticate requests some other way call it first, so that no
+// credential of the server, such as the client certificate of a kind or
+// minikube context, is sent along; the API server would authenticate a client
+// certificate before a bearer token.
+func ClearKubernetesCredentials(cfg *rest.Config) {
+	cfg.ExecProvider = nil
+	cfg.AuthProvider = nil
+	cfg.BearerToken = ""
+	cfg.BearerTokenFile = ""
+	cfg.Username = ""
+	cfg.Password = ""
+	cfg.TLSClientConfig.CertData = nil
+	cfg.TLSClientConfig.CertFile = ""
+	cfg.TLSClientConfig.KeyData = nil
+	cfg.TLSClientConfig.KeyFile = ""
+}
+
+// New constructs a Config populated from the environment, gcloud and build
+// version.
+func New(version string, enableDeleteTools bool, opts ...Option) *Config {
 	provider := os.Getenv("GKE_MCP_PROVIDER")
 	if provider == "" {
 		provider = "vertex-ai"
@@ -149,10 +416,8 @@ func New(version string, enableDeleteTools bool) *Config {
 		dkAPIKey = os.Getenv("GEMINI_API_KEY")
 	}
 
-	return &Config{
+	c := &Config{
 		userAgent:         "gke-mcp/" + version,
-		defaultProjectID:  getDefaultProjectID(),
-		defaultLocation:   getDefaultLocation(),
 		agentProvider:     provider,
 		agentModel:        model,
 		enableDeleteTools: enableDeleteTools,
@@ -160,11 +425,16 @@ func New(version string, enableDeleteTools bool) *Config {
 		dkBaseURL:         dkBaseURL,
 		dkAPIKey:          dkAPIKey,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.loadDefaults()
+	return c
 }
 
 // NewTestConfig constructs a mock configuration for testing purposes.
-func NewTestConfig(project, location, provider, model string) *Config {
-	return &Config{
+func NewTestConfig(project, location, provider, model string, opts ...Option) *Config {
+	c := &Config{
 		userAgent:         "gke-mcp/test",
 		defaultProjectID:  project,
 		defaultLocation:   location,
@@ -172,34 +442,67 @@ func NewTestConfig(project, location, provider, model string) *Config {
 		agentModel:        model,
 		enableDeleteTools: false,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-func getDefaultProjectID() string {
-	projectID, err := getGcloudConfig("core/project")
+// loadDefaults sets the default project and location from the first source
+// that has them: the configuration file, the environment, then the active
+// gcloud configuration, except that a region from either of the latter is
+// preferred over a zone. Without local defaults only the file is read.
+func (c *Config) loadDefaults() {
+	var fileProject, fileLocation setting
+	if c.file != nil {
+		source := "config file " + c.file.path
+		fileProject = setting{c.file.Project, source}
+		fileLocation = setting{c.file.Location, source}
+	}
+	if c.noLocalDefaults {
+		c.defaultProjectID, c.defaultProjectIDSource = fileProject.value, fileProject.source
+		c.defaultLocation, c.defaultLocationSource = fileLocation.value, fileLocation.source
+		return
+	}
+	gcloud, err := activeGcloudConfiguration()
 	if err != nil {
-		log.Printf("Failed to get default project: %v", err)
-		return ""
+		log.Printf("Failed to read gcloud defaults: %v", err)
 	}
-	return projectID
+
+	project := firstSetting(
+		fileProject,
+		envSetting("CLOUDSDK_CORE_PROJECT"),
+		envSetting("GOOGLE_CLOUD_PROJECT"),
+		gcloud.get("core/project"))
+	c.defaultProjectID, c.defaultProjectIDSource = project.value, project.source
+	// Regions are preferred over zones wherever they are set, as gcloud
+	// config get did before.
+	location := firstSetting(
+		fileLocation,
+		envSetting("CLOUDSDK_COMPUTE_REGION"),
+		gcloud.get("compute/region"),
+		envSetting("CLOUDSDK_COMPUTE_ZONE"),
+		gcloud.get("compute/zone"))
+	c.defaultLocation, c.defaultLocationSource = location.value, location.source
 }
 
-func getDefaultLocation() string {
-	region, err := getGcloudConfig("compute/region")
-	if err == nil {
-		return region
-	}
-	zone, err := getGcloudConfig("compute/zone")
-	if err == nil {
-		return zone
-	}
-	return ""
+// setting is a configuration value and where it was read from.
+type setting struct {
+	value  string
+	source string
 }
 
-func getGcloudConfig(key string) (string, error) {
-	// #nosec G204
-	out, err := exec.Command("gcloud", "config", "get", key).Output()
-	if err != nil {
-		return "", err
+// envSetting returns the value of an environment variable.
+func envSetting(name string) setting {
+	return setting{os.Getenv(name), name}
+}
+
+// firstSetting returns the first setting with a value, or an empty setting.
+func firstSetting(settings ...setting) setting {
+	for _, s := range settings {
+		if s.value != "" {
+			return s
+		}
 	}
-	return strings.TrimSpace(string(out)), nil
+	return setting{}
 }
@@ -15,7 +15,17 @@
 package config
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"cloud.google.com/go/auth"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/rest"
 )
 
 func TestNew(t *testing.T) {
@@ -90,6 +100,138 @@ func TestNewConfigWithVersion(t *testing.T) {
 	}
 }
 
+func TestNewWithReadOnly(t *testing.T) {
+	if New("1.0.0", false).ReadOnly() {
+		t.Error("Expected ReadOnly to be false by default")
+	}
+	if !New("1.0.0", false, WithReadOnly(true)).ReadOnly() {
+		t.Error("Expected ReadOnly to be true with WithReadOnly(true)")
+	}
+	if !NewTestConfig("p", "l", "vertex-ai", "m", WithReadOnly(true)).ReadOnly() {
+		t.Error("Expected NewTestConfig to apply WithReadOnly(true)")
+	}
+}
+
+func TestNewWithAllowUnconfirmed(t *testing.T) {
+	if New("1.0.0", false).AllowUnconfirmed() {
+		t.Error("Expected AllowUnconfirmed to be false by default")
+	}
+	if !New("1.0.0", false, WithAllowUnconfirmed(true)).AllowUnconfirmed() {
+		t.Error("Expected AllowUnconfirmed to be true with WithAllowUnconfirmed(true)")
+	}
+}
+
+func TestGoogleClientOptions(t *testing.T) {
+	cfg := NewTestConfig("p", "l", "vertex-ai", "m", WithGoogleClientOptions(option.WithQuotaProject("q")))
+	if got := len(cfg.GoogleClientOptions()); got != 2 {
+		t.Errorf("len(GoogleClientOptions()) = %d, want 2 (user agent and configured option)", got)
+	}
+	if got := len(cfg.GoogleClientOptions(option.WithEndpoint("e"))); got != 3 {
+		t.Errorf("len(GoogleClientOptions(extra)) = %d, want 3", got)
+	}
+}
+
+func TestWithGoogleCredentials(t *testing.T) {
+	if creds := NewTestConfig("p", "l", "vertex-ai", "m").GoogleCredentials(); creds != nil {
+		t.Errorf("GoogleCredentials() = %v, want nil for Application Default Credentials", creds)
+	}
+	creds := auth.NewCredentials(&auth.CredentialsOptions{})
+	cfg := NewTestConfig("p", "l", "vertex-ai", "m", WithGoogleCredentials(creds))
+	if cfg.GoogleCredentials() != creds {
+		t.Errorf("GoogleCredentials() = %v, want the configured credentials", cfg.GoogleCredentials())
+	}
+	if got := len(cfg.GoogleClientOptions()); got != 2 {
+		t.Errorf("len(GoogleClientOptions()) = %d, want 2 (user agent and credentials)", got)
+	}
+}
+
+func TestGoogleRESTClientOptions(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer srv.Close()
+
+	var requests int
+	cfg := NewTestConfig("p", "l", "vertex-ai", "m",
+		// gRPC options may not be combined with the HTTP client.
+		WithGoogleClientOptions(option.WithGRPCDialOption(grpc.WithUserAgent("grpc"))),
+		WithGoogleRESTTransportWrapper(func(rt http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				requests++
+				return rt.RoundTrip(req)
+			})
+		}))
+	opts, err := cfg.GoogleRESTClientOptions(context.Background(), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("GoogleRESTClientOptions() failed: %v", err)
+	}
+	client, _, err := htransport.NewClient(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if requests != 1 {
+		t.Errorf("wrapper saw %d requests, want 1", requests)
+	}
+	if !strings.Contains(userAgent, "gke-mcp/test") {
+		t.Errorf("User-Agent = %q, want the configured user agent", userAgent)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type namedTransport struct {
+	name    string
+	wrapped http.RoundTripper
+}
+
+func (t *namedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.wrapped.RoundTrip(req)
+}
+
+func TestWrapKubernetesTransport(t *testing.T) {
+	wrapper := func(name string) func(http.RoundTripper) http.RoundTripper {
+		return func(rt http.RoundTripper) http.RoundTripper {
+			return &namedTransport{name: name, wrapped: rt}
+		}
+	}
+	cfg := NewTestConfig("p", "l", "vertex-ai", "m",
+		WithKubernetesTransportWrapper(wrapper("inner")),
+		WithKubernetesTransportWrapper(wrapper("outer")))
+
+	rt, ok := cfg.WrapKubernetesTransport(http.DefaultTransport).(*namedTransport)
+	if !ok || rt.name != "outer" {
+		t.Fatalf("outermost transport = %+v, want outer", rt)
+	}
+	if inner, ok := rt.wrapped.(*namedTransport); !ok || inner.name != "inner" || inner.wrapped != http.DefaultTransport {
+		t.Errorf("inner transport = %+v, want inner wrapping the base transport", rt.wrapped)
+	}
+	if got := NewTestConfig("p", "l", "vertex-ai", "m").WrapKubernetesTransport(http.DefaultTransport); got != http.DefaultTransport {
+		t.Errorf("WrapKubernetesTransport() without wrappers = %v, want the base transport", got)
+	}
+}
+
+func TestConfigureKubernetes(t *testing.T) {
+	cfg := NewTestConfig("p", "l", "vertex-ai", "m",
+		WithKubernetesConfigHook(func(rc *rest.Config) { rc.BearerToken = "" }),
+		WithKubernetesConfigHook(func(rc *rest.Config) { rc.UserAgent = "hooked" }))
+
+	rc := &rest.Config{BearerToken: "server-token"}
+	cfg.ConfigureKubernetes(rc)
+	if rc.BearerToken != "" || rc.UserAgent != "hooked" {
+		t.Errorf("ConfigureKubernetes() = %+v, want both hooks applied", rc)
+	}
+}
+
 func TestConfigFields(t *testing.T) {
 	cfg := &Config{
 		userAgent:         "test-agent",
@@ -128,45 +270,6 @@ func TestConfigUserAgentFormat(t *testing.T) {
 	}
 }
 
-func TestGetGcloudConfigTrimsWhitespace(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
-	out, err := getGcloudConfig("core/project")
-	if err != nil {
-		t.Logf("gcloud config get failed (expected if not configured): %v", err)
-	}
-	if out != "" {
-		result := out
-		if result != "" {
-			if result != out {
-				t.Errorf("getGcloudConfig() should trim whitespace, got: %q", out)
-			}
-		}
-	}
-	_ = out
-	_ = err
-}
-
-func TestGetDefaultLocationNotPanic(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
-	loc := getDefaultLocation()
-	t.Logf("Default location: %s", loc)
-}
-
-func TestGetDefaultProjectIDNotPanic(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
-	projectID := getDefaultProjectID()
-	t.Logf("Default project ID: %s", projectID)
-}
-
 func TestConfigNilFields(t *testing.T) {
 	cfg := &Config{}
 	if cfg.UserAgent() != "" {
@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// File is the on-disk configuration file for the server, in YAML or JSON.
+//
+// Values set by command line flags take precedence over the file.
+type File struct {
+	// EnableTools lists tool names or glob patterns to register; all tools if empty.
+	EnableTools []string `json:"enableTools,omitempty"`
+	// DisableTools lists tool names or glob patterns to never register.
+	DisableTools []string `json:"disableTools,omitempty"`
+	// Project is the default GCP project ID.
+	Project string `json:"project,omitempty"`
+	// Location is the default GCP region or zone.
+	Location string `json:"location,omitempty"`
+
+	// path is the file the configuration was read from.
+	path string
+}
+
+// LoadFile reads and parses the configuration file at path. Unknown fields are
+// rejected so that typos do not silently change behavior.
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	f := File{path: path}
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return &f, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeConfigFile(t, `
+enableTools:
+  - "*_k8s_*"
+  - list_clusters
+disableTools:
+  - delete_k8s_resource
+project: my-project
+location: us-central1
+`)
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() failed: %v", err)
+	}
+	if want := []string{"*_k8s_*", "list_clusters"}; !slices.Equal(f.EnableTools, want) {
+		t.Errorf("EnableTools = %v, want %v", f.EnableTools, want)
+	}
+	if want := []string{"delete_k8s_resource"}; !slices.Equal(f.DisableTools, want) {
+		t.Errorf("DisableTools = %v, want %v", f.DisableTools, want)
+	}
+	if f.Project != "my-project" || f.Location != "us-central1" {
+		t.Errorf("Project, Location = %q, %q; want my-project, us-central1", f.Project, f.Location)
+	}
+}
+
+func TestLoadFile_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.yaml")},
+		{name: "unknown field", path: writeConfigFile(t, "enabledTools: [list_clusters]\n")},
+		{name: "wrong type", path: writeConfigFile(t, "enableTools: list_clusters\n")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := LoadFile(tc.path); err == nil {
+				t.Error("LoadFile() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestWithoutLocalDefaults(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "env-project")
+	t.Setenv("CLOUDSDK_COMPUTE_REGION", "env-region")
+	if c := New("v", false, WithoutLocalDefaults()); c.DefaultProjectID() != "" || c.DefaultLocation() != "" {
+		t.Errorf("defaults = %q, %q; want none", c.DefaultProjectID(), c.DefaultLocation())
+	}
+
+	f, err := LoadFile(writeConfigFile(t, "project: file-project\n"))
+	if err != nil {
+		t.Fatalf("LoadFile() failed: %v", err)
+	}
+	c := New("v", false, WithFile(f), WithoutLocalDefaults())
+	if c.DefaultProjectID() != "file-project" || c.DefaultLocation() != "" {
+		t.Errorf("defaults = %q, %q; want only the project of the file", c.DefaultProjectID(), c.DefaultLocation())
+	}
+}
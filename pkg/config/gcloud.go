@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// gcloudConfiguration holds the properties of a named gcloud configuration,
+// read from its file without running gcloud.
+type gcloudConfiguration struct {
+	name       string
+	properties map[string]string // by "section/name", e.g. "core/project"
+}
+
+// gcloudConfigDir returns the directory of gcloud's user configuration.
+func gcloudConfigDir() (string, error) {
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return dir, nil
+	}
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "gcloud"), nil
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "gcloud"), nil
+}
+
+// activeGcloudConfiguration reads the active gcloud configuration. It returns
+// nil if gcloud has not been configured.
+func activeGcloudConfiguration() (*gcloudConfiguration, error) {
+	dir, err := gcloudConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	name := os.Getenv("CLOUDSDK_ACTIVE_CONFIG_NAME")
+	if name == "" {
+		data, err := os.ReadFile(filepath.Join(dir, "active_config")) // #nosec G304
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read the active gcloud configuration: %w", err)
+		}
+		name = strings.TrimSpace(string(data))
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "configurations", "config_"+name)) // #nosec G304
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gcloud configuration %s: %w", name, err)
+	}
+	return &gcloudConfiguration{name: name, properties: parseProperties(data)}, nil
+}
+
+// parseProperties parses a gcloud properties file, which is in INI format.
+func parseProperties(data []byte) map[string]string {
+	properties := make(map[string]string)
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "", strings.HasPrefix(line, "#"), strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+		default:
+			name, value, ok := strings.Cut(line, "=")
+			if ok && section != "" {
+				properties[section+"/"+strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return properties
+}
+
+// get returns a property, such as "core/project". g may be nil.
+func (g *gcloudConfiguration) get(key string) setting {
+	if g == nil || g.properties[key] == "" {
+		return setting{}
+	}
+	return setting{g.properties[key], fmt.Sprintf("gcloud configuration %q", g.name)}
+}
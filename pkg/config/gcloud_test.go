@@ -0,0 +1,210 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeGcloud points gcloud's configuration directory at a temporary directory
+// with the given configurations, clears the environment variables that take
+// precedence over them and returns the directory.
+func fakeGcloud(t *testing.T, active string, configurations map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("CLOUDSDK_CONFIG", dir)
+	for _, name := range []string{"CLOUDSDK_ACTIVE_CONFIG_NAME", "CLOUDSDK_CORE_PROJECT", "GOOGLE_CLOUD_PROJECT", "CLOUDSDK_COMPUTE_REGION", "CLOUDSDK_COMPUTE_ZONE"} {
+		t.Setenv(name, "")
+	}
+	if active != "" {
+		if err := os.WriteFile(filepath.Join(dir, "active_config"), []byte(active+"\n"), 0600); err != nil {
+			t.Fatalf("failed to write active_config: %v", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "configurations"), 0700); err != nil {
+		t.Fatalf("failed to create configurations directory: %v", err)
+	}
+	for name, content := range configurations {
+		if err := os.WriteFile(filepath.Join(dir, "configurations", "config_"+name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write configuration %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestParseProperties(t *testing.T) {
+	got := parseProperties([]byte(`
+# comment
+; another comment
+[core]
+account = user@example.com
+Project=  my-project
+disable_usage_reporting = True
+
+[compute]
+zone = us-central1-a
+ignored
+`))
+	want := map[string]string{
+		"core/account":                 "user@example.com",
+		"core/project":                 "my-project",
+		"core/disable_usage_reporting": "True",
+		"compute/zone":                 "us-central1-a",
+	}
+	if len(got) != len(want) {
+		t.Errorf("parseProperties() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("parseProperties()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestActiveGcloudConfiguration(t *testing.T) {
+	fakeGcloud(t, "work", map[string]string{
+		"default": "[core]\nproject = default-project\n",
+		"work":    "[core]\nproject = work-project\n",
+	})
+
+	g, err := activeGcloudConfiguration()
+	if err != nil {
+		t.Fatalf("activeGcloudConfiguration() failed: %v", err)
+	}
+	if got := g.get("core/project"); got.value != "work-project" || got.source != `gcloud configuration "work"` {
+		t.Errorf("core/project = %+v, want work-project from the work configuration", got)
+	}
+
+	t.Setenv("CLOUDSDK_ACTIVE_CONFIG_NAME", "default")
+	g, err = activeGcloudConfiguration()
+	if err != nil {
+		t.Fatalf("activeGcloudConfiguration() failed: %v", err)
+	}
+	if got := g.get("core/project").value; got != "default-project" {
+		t.Errorf("core/project = %q with CLOUDSDK_ACTIVE_CONFIG_NAME, want default-project", got)
+	}
+}
+
+func TestActiveGcloudConfigurationNotConfigured(t *testing.T) {
+	dir := fakeGcloud(t, "", nil)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(dir, "missing"))
+
+	g, err := activeGcloudConfiguration()
+	if err != nil || g != nil {
+		t.Fatalf("activeGcloudConfiguration() = %v, %v; want nil, nil", g, err)
+	}
+	if got := g.get("core/project"); got != (setting{}) {
+		t.Errorf("get() on a nil configuration = %+v, want empty", got)
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	fakeGcloud(t, "", map[string]string{
+		"default": "[core]\nproject = gcloud-project\n[compute]\nzone = us-central1-a\n",
+	})
+	file := &File{Project: "file-project", path: "/etc/gke-mcp.yaml"}
+
+	tests := []struct {
+		name            string
+		env             map[string]string
+		file            *File
+		wantProject     string
+		wantProjectSrc  string
+		wantLocation    string
+		wantLocationSrc string
+	}{
+		{
+			name:            "gcloud",
+			wantProject:     "gcloud-project",
+			wantProjectSrc:  `gcloud configuration "default"`,
+			wantLocation:    "us-central1-a",
+			wantLocationSrc: `gcloud configuration "default"`,
+		},
+		{
+			name:            "environment",
+			env:             map[string]string{"GOOGLE_CLOUD_PROJECT": "env-project", "CLOUDSDK_COMPUTE_REGION": "europe-west1"},
+			wantProject:     "env-project",
+			wantProjectSrc:  "GOOGLE_CLOUD_PROJECT",
+			wantLocation:    "europe-west1",
+			wantLocationSrc: "CLOUDSDK_COMPUTE_REGION",
+		},
+		{
+			name:            "gcloud variable before standard variable",
+			env:             map[string]string{"GOOGLE_CLOUD_PROJECT": "env-project", "CLOUDSDK_CORE_PROJECT": "sdk-project"},
+			wantProject:     "sdk-project",
+			wantProjectSrc:  "CLOUDSDK_CORE_PROJECT",
+			wantLocation:    "us-central1-a",
+			wantLocationSrc: `gcloud configuration "default"`,
+		},
+		{
+			name:            "config file",
+			env:             map[string]string{"CLOUDSDK_CORE_PROJECT": "sdk-project"},
+			file:            file,
+			wantProject:     "file-project",
+			wantProjectSrc:  "config file /etc/gke-mcp.yaml",
+			wantLocation:    "us-central1-a",
+			wantLocationSrc: `gcloud configuration "default"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			c := New("test", false, WithFile(tc.file))
+			if c.DefaultProjectID() != tc.wantProject || c.DefaultProjectIDSource() != tc.wantProjectSrc {
+				t.Errorf("project = %q from %q, want %q from %q", c.DefaultProjectID(), c.DefaultProjectIDSource(), tc.wantProject, tc.wantProjectSrc)
+			}
+			if c.DefaultLocation() != tc.wantLocation || c.DefaultLocationSource() != tc.wantLocationSrc {
+				t.Errorf("location = %q from %q, want %q from %q", c.DefaultLocation(), c.DefaultLocationSource(), tc.wantLocation, tc.wantLocationSrc)
+			}
+		})
+	}
+}
+
+func TestNewDefaultsRegionBeforeZone(t *testing.T) {
+	tests := []struct {
+		name            string
+		gcloud          string
+		wantLocation    string
+		wantLocationSrc string
+	}{
+		{
+			name:            "gcloud region before environment zone",
+			gcloud:          "[compute]\nregion = us-east1\nzone = us-east1-b\n",
+			wantLocation:    "us-east1",
+			wantLocationSrc: `gcloud configuration "default"`,
+		},
+		{
+			name:            "environment zone before gcloud zone",
+			gcloud:          "[compute]\nzone = us-east1-b\n",
+			wantLocation:    "europe-west1-c",
+			wantLocationSrc: "CLOUDSDK_COMPUTE_ZONE",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeGcloud(t, "", map[string]string{"default": tc.gcloud})
+			t.Setenv("CLOUDSDK_COMPUTE_ZONE", "europe-west1-c")
+
+			c := New("test", false)
+			if c.DefaultLocation() != tc.wantLocation || c.DefaultLocationSource() != tc.wantLocationSrc {
+				t.Errorf("location = %q from %q, want %q from %q", c.DefaultLocation(), c.DefaultLocationSource(), tc.wantLocation, tc.wantLocationSrc)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"path"
+)
+
+// ToolFilter selects which tools are registered by name.
+//
+// Entries are tool names or glob patterns as understood by path.Match, e.g.
+// "k8s_*" or "*_node_pool". If Enable is non-empty, only tools matching one of
+// its entries are allowed. Tools matching an entry in Disable are never allowed.
+type ToolFilter struct {
+	Enable  []string
+	Disable []string
+}
+
+// Validate returns an error if any entry is not a valid glob pattern.
+func (f ToolFilter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Enable...), f.Disable...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the tool with the given name passes the filter.
+func (f ToolFilter) Allows(name string) bool {
+	if len(f.Enable) > 0 && !matchAny(f.Enable, name) {
+		return false
+	}
+	return !matchAny(f.Disable, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestToolFilterAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ToolFilter
+		tool   string
+		want   bool
+	}{
+		{name: "empty filter", filter: ToolFilter{}, tool: "list_clusters", want: true},
+		{name: "enabled by name", filter: ToolFilter{Enable: []string{"list_clusters"}}, tool: "list_clusters", want: true},
+		{name: "not in enable list", filter: ToolFilter{Enable: []string{"list_clusters"}}, tool: "get_cluster", want: false},
+		{name: "enabled by prefix glob", filter: ToolFilter{Enable: []string{"get_k8s_*"}}, tool: "get_k8s_logs", want: true},
+		{name: "enabled by suffix glob", filter: ToolFilter{Enable: []string{"*_node_pool"}}, tool: "update_node_pool", want: true},
+		{name: "suffix glob does not match plural", filter: ToolFilter{Enable: []string{"*_node_pool"}}, tool: "list_node_pools", want: false},
+		{name: "disabled by name", filter: ToolFilter{Disable: []string{"create_cluster"}}, tool: "create_cluster", want: false},
+		{name: "disabled by glob", filter: ToolFilter{Disable: []string{"*k8s*"}}, tool: "apply_k8s_manifest", want: false},
+		{name: "disable wins over enable", filter: ToolFilter{Enable: []string{"*"}, Disable: []string{"delete_*"}}, tool: "delete_cluster", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.Allows(tc.tool); got != tc.want {
+				t.Errorf("Allows(%q) = %v, want %v", tc.tool, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToolFilterValidate(t *testing.T) {
+	if err := (ToolFilter{Enable: []string{"k8s_*"}, Disable: []string{"*_node_pool"}}).Validate(); err != nil {
+		t.Errorf("Validate() failed for valid patterns: %v", err)
+	}
+	if err := (ToolFilter{Disable: []string{"[k8s"}}).Validate(); err == nil {
+		t.Error("Validate() succeeded for malformed pattern, want error")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package confirm asks the user to approve destructive tool calls through MCP
+// elicitation before they are executed.
+package confirm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var (
+	// ErrDeclined is returned when the user does not approve the operation.
+	ErrDeclined = errors.New("the user did not confirm the operation, so it was not performed")
+
+	// ErrUnsupported is returned when the client cannot ask the user and the
+	// server does not allow unconfirmed operations.
+	ErrUnsupported = errors.New("the operation needs user confirmation, but the MCP client does not support elicitation; start the server with --allow-unconfirmed to perform it without confirmation")
+)
+
+// Field is the boolean property of the requested schema that the user must set
+// to approve the operation.
+const Field = "confirm"
+
+var requestedSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		Field: map[string]any{
+			"type":        "boolean",
+			"title":       "Proceed",
+			"description": "Check to perform the operation described above.",
+		},
+	},
+	"required": []string{Field},
+}
+
+// supported reports whether the client of req can be asked for confirmation.
+func supported(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// inputID identifies the confirmation for message, so that a response given
+// for one target is never taken as approval of another.
+func inputID(message string) string {
+	sum := sha256.Sum256([]byte(message))
+	return "confirm-" + hex.EncodeToString(sum[:8])
+}
+
+// Request asks the user of the client that sent req to approve the operation
+// described by message, which should name its exact target and effect.
+//
+// The question is sent as an input request of a multi round-trip tool call: on
+// the first call Request returns a result that the handler must return
+// unchanged, and the client calls the tool again with the user's answer. When
+// Request returns nil for both the result and the error, the user explicitly
+// accepted (or the client does not support elicitation and c allows
+// unconfirmed operations) and the handler may proceed.
+func Request(req *mcp.CallToolRequest, c *config.Config, message string) (*mcp.CallToolResult, error) {
+	if !supported(req) {
+		if c != nil && c.AllowUnconfirmed() {
+			return nil, nil
+		}
+		return nil, ErrUnsupported
+	}
+
+	id := inputID(message)
+	resp, ok := req.Params.InputResponses[id]
+	if !ok {
+		return &mcp.CallToolResult{
+			InputRequests: mcp.InputRequestMap{
+				id: &mcp.ElicitParams{
+					Message:         message,
+					RequestedSchema: requestedSchema,
+				},
+			},
+		}, nil
+	}
+	res, ok := resp.(*mcp.ElicitResult)
+	if !ok || res.Action != "accept" {
+		return nil, ErrDeclined
+	}
+	if ok, _ := res.Content[Field].(bool); !ok {
+		return nil, ErrDeclined
+	}
+	return nil, nil
+}
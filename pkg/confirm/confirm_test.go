@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type emptyArgs struct{}
+
+// callWithConfirmation connects a client using handler (nil for a client
+// without elicitation support) to a server whose only tool requests
+// confirmation, calls the tool and returns the message the client was shown
+// along with the error from Request.
+func callWithConfirmation(t *testing.T, c *config.Config, handler func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)) (string, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	var confirmErr error
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "delete_thing"}, func(_ context.Context, req *mcp.CallToolRequest, _ *emptyArgs) (*mcp.CallToolResult, any, error) {
+		res, err := Request(req, c, "Delete thing?")
+		if res != nil {
+			return res, nil, nil
+		}
+		confirmErr = err
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil, nil
+	})
+
+	var shown string
+	opts := &mcp.ClientOptions{}
+	if handler != nil {
+		opts.ElicitationHandler = func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			shown = req.Params.Message
+			return handler(ctx, req)
+		}
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, opts)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	defer func() { _ = serverSession.Close() }()
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() failed: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "delete_thing", Arguments: map[string]any{}}); err != nil {
+		t.Fatalf("CallTool() failed: %v", err)
+	}
+	return shown, confirmErr
+}
+
+func respond(action string, content map[string]any) func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+	return func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		return &mcp.ElicitResult{Action: action, Content: content}, nil
+	}
+}
+
+func TestRequest(t *testing.T) {
+	strict := config.NewTestConfig("p", "l", "vertex-ai", "gemini-2.5-pro")
+	permissive := config.NewTestConfig("p", "l", "vertex-ai", "gemini-2.5-pro", config.WithAllowUnconfirmed(true))
+
+	tests := []struct {
+		name    string
+		c       *config.Config
+		handler func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)
+		wantErr error
+	}{
+		{name: "accepted", c: strict, handler: respond("accept", map[string]any{"confirm": true})},
+		{name: "accepted unchecked", c: strict, handler: respond("accept", map[string]any{"confirm": false}), wantErr: ErrDeclined},
+		{name: "declined", c: strict, handler: respond("decline", nil), wantErr: ErrDeclined},
+		{name: "cancelled", c: permissive, handler: respond("cancel", nil), wantErr: ErrDeclined},
+		{name: "unsupported", c: strict, wantErr: ErrUnsupported},
+		{name: "unsupported allowed", c: permissive},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			shown, err := callWithConfirmation(t, tc.c, tc.handler)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Request() = %v, want %v", err, tc.wantErr)
+			}
+			if tc.handler != nil && shown != "Delete thing?" {
+				t.Errorf("client was shown %q, want %q", shown, "Delete thing?")
+			}
+		})
+	}
+}
+
+func TestRequestWithoutSession(t *testing.T) {
+	if res, err := Request(&mcp.CallToolRequest{}, nil, "Delete thing?"); res != nil || !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Request() without a session = %v, %v, want nil, %v", res, err, ErrUnsupported)
+	}
+}
+
+func TestInputID(t *testing.T) {
+	if inputID("Delete a?") == inputID("Delete b?") {
+		t.Error("inputID() is the same for different messages")
+	}
+	if inputID("Delete a?") != inputID("Delete a?") {
+		t.Error("inputID() differs for the same message")
+	}
+}
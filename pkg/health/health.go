@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health serves liveness and readiness endpoints for the HTTP server
+// mode and tracks in-flight tool calls so they can be drained on shutdown.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrPending is the initial state of every readiness condition.
+var ErrPending = errors.New("pending")
+
+// Status tracks named readiness conditions. The server is ready once every
+// condition has been set to a nil error. It is safe for concurrent use.
+type Status struct {
+	mu         sync.RWMutex
+	conditions map[string]error
+}
+
+// NewStatus returns a Status with the given conditions, all pending.
+func NewStatus(conditions ...string) *Status {
+	s := &Status{conditions: make(map[string]error)}
+	for _, name := range conditions {
+		s.conditions[name] = ErrPending
+	}
+	return s
+}
+
+// Set records the result of a condition, adding it if it is new.
+func (s *Status) Set(name string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conditions[name] = err
+}
+
+// Failing returns the sorted names of conditions that are not satisfied.
+func (s *Status) Failing() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var failing []string
+	for name, err := range s.conditions {
+		if err != nil {
+			failing = append(failing, name)
+		}
+	}
+	slices.Sort(failing)
+	return failing
+}
+
+type response struct {
+	Status  string   `json:"status"`
+	Failing []string `json:"failing,omitempty"`
+}
+
+// LivenessHandler reports that the process is serving requests.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, response{Status: "ok"})
+	})
+}
+
+// ReadinessHandler responds with 200 when every condition is satisfied and
+// 503 otherwise. Only the names of failing conditions are returned, since
+// probes are not authenticated; the errors themselves are logged by callers.
+func (s *Status) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing := s.Failing(); len(failing) > 0 {
+			writeJSON(w, http.StatusServiceUnavailable, response{Status: "not ready", Failing: failing})
+			return
+		}
+		writeJSON(w, http.StatusOK, response{Status: "ok"})
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// drainPollInterval is how often InFlight.Wait checks for completed calls.
+const drainPollInterval = 50 * time.Millisecond
+
+// InFlight counts tool calls that are being handled.
+type InFlight struct {
+	mu sync.Mutex
+	n  int
+}
+
+// Middleware is MCP server middleware that counts in-flight tools/call
+// requests. Install it with mcp.Server.AddReceivingMiddleware.
+func (f *InFlight) Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		f.add(1)
+		defer f.add(-1)
+		return next(ctx, method, req)
+	}
+}
+
+func (f *InFlight) add(delta int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n += delta
+}
+
+// Count returns the number of tool calls in flight.
+func (f *InFlight) Count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.n
+}
+
+// Wait blocks until no tool calls are in flight or ctx is done, in which case
+// it returns the context's error.
+func (f *InFlight) Wait(ctx context.Context) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for f.Count() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
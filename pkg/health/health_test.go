@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func probe(t *testing.T, h http.Handler) (int, response) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var resp response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("probe returned invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+func TestReadinessHandler(t *testing.T) {
+	s := NewStatus("adc", "tools")
+	h := s.ReadinessHandler()
+
+	code, resp := probe(t, h)
+	if code != http.StatusServiceUnavailable || !cmp.Equal(resp.Failing, []string{"adc", "tools"}) {
+		t.Errorf("initial readiness = %d %+v, want 503 with adc and tools failing", code, resp)
+	}
+
+	s.Set("tools", nil)
+	s.Set("adc", errors.New("Unauthenticated: credentials for project-x expired"))
+	code, resp = probe(t, h)
+	if code != http.StatusServiceUnavailable || !cmp.Equal(resp.Failing, []string{"adc"}) {
+		t.Errorf("readiness = %d %+v, want 503 with adc failing", code, resp)
+	}
+
+	s.Set("adc", nil)
+	if code, resp = probe(t, h); code != http.StatusOK || resp.Status != "ok" {
+		t.Errorf("readiness = %d %+v, want 200 ok", code, resp)
+	}
+
+	s.Set("shutdown", errors.New("shutting down"))
+	if code, _ = probe(t, h); code != http.StatusServiceUnavailable {
+		t.Errorf("readiness while shutting down = %d, want 503", code)
+	}
+	if code, _ = probe(t, LivenessHandler()); code != http.StatusOK {
+		t.Errorf("liveness while shutting down = %d, want 200", code)
+	}
+}
+
+func TestInFlight(t *testing.T) {
+	var f InFlight
+	release := make(chan struct{})
+	started := make(chan struct{})
+	h := f.Middleware(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		close(started)
+		<-release
+		return &mcp.CallToolResult{}, nil
+	})
+
+	go func() {
+		_, _ = h(context.Background(), "tools/call", &mcp.CallToolRequest{})
+	}()
+	<-started
+	if got := f.Count(); got != 1 {
+		t.Fatalf("Count() = %d, want 1", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*drainPollInterval)
+	defer cancel()
+	if err := f.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() with a call in flight = %v, want deadline exceeded", err)
+	}
+
+	close(release)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := f.Wait(ctx); err != nil {
+		t.Errorf("Wait() after the call completed = %v, want nil", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package impersonation runs the server as a narrowly scoped identity: Google
+// Cloud API requests are made as a service account that the server's
+// Application Default Credentials impersonate, and Kubernetes API requests
+// impersonate a Kubernetes user and groups.
+package impersonation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"cloud.google.com/go/auth"
+	"cloud.google.com/go/auth/credentials/impersonate"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"k8s.io/client-go/rest"
+)
+
+// cloudPlatformScope is the OAuth scope of the impersonated tokens.
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// Options configures impersonation.
+type Options struct {
+	// ServiceAccount is the email of the service account that Google Cloud
+	// API requests are made as.
+	ServiceAccount string
+	// KubernetesUser is the user that Kubernetes API requests impersonate.
+	KubernetesUser string
+	// KubernetesGroups are the groups that Kubernetes API requests
+	// impersonate. They require KubernetesUser.
+	KubernetesGroups []string
+}
+
+// Validate returns an error if the options are inconsistent.
+func (o Options) Validate() error {
+	if o.ServiceAccount != "" && !strings.Contains(o.ServiceAccount, "@") {
+		return fmt.Errorf("%q is not a service account email", o.ServiceAccount)
+	}
+	if len(o.KubernetesGroups) > 0 && o.KubernetesUser == "" {
+		return errors.New("impersonating Kubernetes groups requires a Kubernetes user")
+	}
+	return nil
+}
+
+// NewCredentials returns credentials of the service account, with short-lived
+// tokens that the server's Application Default Credentials generate for it.
+// Those credentials need the Service Account Token Creator role on the service
+// account.
+func NewCredentials(serviceAccount string) (*auth.Credentials, error) {
+	return newCredentials(&impersonate.CredentialsOptions{
+		TargetPrincipal: serviceAccount,
+		Scopes:          []string{cloudPlatformScope},
+	})
+}
+
+func newCredentials(opts *impersonate.CredentialsOptions) (*auth.Credentials, error) {
+	creds, err := impersonate.NewCredentials(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to impersonate service account %s: %w", opts.TargetPrincipal, err)
+	}
+	return creds, nil
+}
+
+// KubernetesCredentialsHook returns a hook that replaces the credentials of a
+// Kubernetes client configuration, such as the gcloud exec plugin or client
+// certificate of a kubeconfig context, with tokens of creds. Requests to clusters read from
+// the kubeconfig are then made as the service account too, rather than as the
+// server's own identity.
+func KubernetesCredentialsHook(creds *auth.Credentials) func(*rest.Config) {
+	return func(cfg *rest.Config) {
+		config.ClearKubernetesCredentials(cfg)
+		cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &roundTripper{creds: creds, wrapped: rt}
+		})
+	}
+}
+
+type roundTripper struct {
+	creds   *auth.Credentials
+	wrapped http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.creds.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token of the impersonated service account: %w", err)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token.Value)
+	return t.wrapped.RoundTrip(req)
+}
+
+// KubernetesConfigHook returns a hook that makes the requests of a Kubernetes
+// client configuration impersonate the user and groups of o. The credentials
+// of the configuration need RBAC permission to impersonate them.
+func KubernetesConfigHook(o Options) func(*rest.Config) {
+	return func(cfg *rest.Config) {
+		cfg.Impersonate = rest.ImpersonationConfig{
+			UserName: o.KubernetesUser,
+			Groups:   o.KubernetesGroups,
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impersonation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/auth"
+	"cloud.google.com/go/auth/credentials/impersonate"
+	"google.golang.org/api/cloudtrace/v1"
+	"google.golang.org/api/option"
+	"google.golang.org/api/transport"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const testServiceAccount = "agent@p.iam.gserviceaccount.com"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{"empty", Options{}, ""},
+		{"all", Options{ServiceAccount: testServiceAccount, KubernetesUser: "agent", KubernetesGroups: []string{"readers"}}, ""},
+		{"not an email", Options{ServiceAccount: "agent"}, "not a service account email"},
+		{"groups without user", Options{KubernetesGroups: []string{"readers"}}, "requires a Kubernetes user"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// redirectTransport sends every request to target, as the IAM Credentials
+// API endpoint cannot be overridden.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewCredentials(t *testing.T) {
+	var generated []string
+	iam := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		generated = append(generated, r.URL.Path)
+		var req struct {
+			Scope []string `json:"scope"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !slices.Contains(req.Scope, cloudPlatformScope) {
+			http.Error(w, fmt.Sprintf("bad request %+v: %v", req, err), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"accessToken":"sa-token","expireTime":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer iam.Close()
+	target, _ := url.Parse(iam.URL)
+
+	var authorization string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+
+	creds, err := newCredentials(&impersonate.CredentialsOptions{
+		TargetPrincipal: testServiceAccount,
+		Scopes:          []string{cloudPlatformScope},
+		Client:          &http.Client{Transport: &redirectTransport{target: target}},
+	})
+	if err != nil {
+		t.Fatalf("newCredentials() failed: %v", err)
+	}
+	opts := []option.ClientOption{option.WithAuthCredentials(creds)}
+	ctx := context.Background()
+
+	svc, err := cloudtrace.NewService(ctx, append(opts, option.WithEndpoint(api.URL))...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := svc.Projects.Traces.List("p").Context(ctx).Do(); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if authorization != "Bearer sa-token" {
+		t.Errorf("Authorization = %q, want the service account's token", authorization)
+	}
+	if want := "/v1/projects/-/serviceAccounts/" + testServiceAccount + ":generateAccessToken"; len(generated) != 1 || generated[0] != want {
+		t.Errorf("IAM requests = %q, want one to %s", generated, want)
+	}
+
+	// GKE control planes are sent tokens of the same credentials.
+	transportCreds, err := transport.Creds(ctx, opts...)
+	if err != nil {
+		t.Fatalf("transport.Creds() failed: %v", err)
+	}
+	token, err := transportCreds.TokenSource.Token()
+	if err != nil {
+		t.Fatalf("Token() failed: %v", err)
+	}
+	if token.AccessToken != "sa-token" {
+		t.Errorf("AccessToken = %q, want the service account's token", token.AccessToken)
+	}
+}
+
+type staticTokenProvider string
+
+func (p staticTokenProvider) Token(context.Context) (*auth.Token, error) {
+	return &auth.Token{Value: string(p), Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func TestKubernetesCredentialsHook(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// The exec plugin of a gcloud kubeconfig context would authenticate as
+	// the server's own identity.
+	cfg := &rest.Config{
+		Host:         srv.URL,
+		BearerToken:  "server-token",
+		ExecProvider: &clientcmdapi.ExecConfig{Command: "gke-gcloud-auth-plugin", APIVersion: "client.authentication.k8s.io/v1beta1"},
+	}
+	creds := auth.NewCredentials(&auth.CredentialsOptions{TokenProvider: staticTokenProvider("sa-token")})
+	KubernetesCredentialsHook(creds)(cfg)
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{}); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if authorization != "Bearer sa-token" {
+		t.Errorf("Authorization = %q, want the service account's token", authorization)
+	}
+}
+
+func TestKubernetesCredentialsHookClientCertificate(t *testing.T) {
+	// kind and minikube contexts authenticate with a client certificate,
+	// which the API server would check before the bearer token.
+	cfg := &rest.Config{
+		Host: "https://127.0.0.1:6443",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:   []byte("ca"),
+			CertData: []byte("cert"),
+			KeyData:  []byte("key"),
+			CertFile: "/home/user/.minikube/profiles/minikube/client.crt",
+			KeyFile:  "/home/user/.minikube/profiles/minikube/client.key",
+		},
+	}
+	creds := auth.NewCredentials(&auth.CredentialsOptions{TokenProvider: staticTokenProvider("sa-token")})
+	KubernetesCredentialsHook(creds)(cfg)
+	want := rest.TLSClientConfig{CAData: []byte("ca")}
+	if !reflect.DeepEqual(cfg.TLSClientConfig, want) {
+		t.Errorf("TLSClientConfig = %+v, want only the CA kept", cfg.TLSClientConfig)
+	}
+}
+
+func TestKubernetesConfigHook(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	cfg := &rest.Config{Host: srv.URL, BearerToken: "server-token"}
+	KubernetesConfigHook(Options{KubernetesUser: "agent", KubernetesGroups: []string{"readers", "auditors"}})(cfg)
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	_, _ = client.CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{})
+
+	if got := header.Get("Impersonate-User"); got != "agent" {
+		t.Errorf("Impersonate-User = %q, want agent", got)
+	}
+	if got := header.Values("Impersonate-Group"); !slices.Equal(got, []string{"readers", "auditors"}) {
+		t.Errorf("Impersonate-Group = %q, want readers and auditors", got)
+	}
+	if got := header.Get("Authorization"); got != "Bearer server-token" {
+		t.Errorf("Authorization = %q, want the server's token", got)
+	}
+}
@@ -37,9 +37,10 @@ func NewClient(ctx context.Context, cfg *config.Config) (model.LLM, error) {
 	switch strings.ToLower(strings.TrimSpace(cfg.AgentProvider())) {
 	case "vertex-ai":
 		llm, err := gemini.NewModel(ctx, cfg.AgentModel(), &genai.ClientConfig{
-			Project:  cfg.DefaultProjectID(),
-			Backend:  genai.BackendVertexAI,
-			Location: cfg.DefaultLocation(),
+			Project:     cfg.DefaultProjectID(),
+			Backend:     genai.BackendVertexAI,
+			Location:    cfg.DefaultLocation(),
+			Credentials: cfg.GoogleCredentials(),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize Vertex AI model: %w", err)
@@ -0,0 +1,203 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exports Prometheus metrics about MCP tool calls, sessions and
+// the Google Cloud and Kubernetes API requests made on their behalf.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "gke_mcp"
+
+// unknownTool labels calls that failed before reaching a registered tool, so
+// that arbitrary names sent by clients do not create new series.
+const unknownTool = "unknown"
+
+// Outbound request targets.
+const (
+	targetGoogleCloud = "gcp"
+	targetKubernetes  = "kubernetes"
+)
+
+// Metrics holds the collectors exported by the server. Each Metrics has its
+// own registry, so it can be created more than once in tests.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	toolCalls        *prometheus.CounterVec
+	toolErrors       *prometheus.CounterVec
+	toolDuration     *prometheus.HistogramVec
+	outboundRequests *prometheus.CounterVec
+}
+
+// New creates a Metrics with all collectors registered, including the standard
+// Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_calls_total",
+			Help:      "Number of MCP tool calls.",
+		}, []string{"tool"}),
+		toolErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_errors_total",
+			Help:      "Number of MCP tool calls that returned an error.",
+		}, []string{"tool"}),
+		toolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tool_call_duration_seconds",
+			Help:      "Latency of MCP tool calls.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}, []string{"tool"}),
+		outboundRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbound_requests_total",
+			Help:      "Number of requests made to Google Cloud and Kubernetes APIs.",
+		}, []string{"target", "service", "method", "code"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.toolCalls,
+		m.toolErrors,
+		m.toolDuration,
+		m.outboundRequests,
+	)
+	return m
+}
+
+// Handler returns an http.Handler serving the metrics in the Prometheus
+// exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveSessions exports the number of sessions currently connected to s.
+func (m *Metrics) ObserveSessions(s *mcp.Server) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_sessions",
+		Help:      "Number of connected MCP sessions.",
+	}, func() float64 {
+		n := 0
+		for range s.Sessions() {
+			n++
+		}
+		return float64(n)
+	}))
+}
+
+// Middleware is MCP server middleware that records every tools/call request.
+// Install it with mcp.Server.AddReceivingMiddleware.
+func (m *Metrics) Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		callReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		start := time.Now()
+		res, err := next(ctx, method, req)
+
+		tool := callReq.Params.Name
+		if err != nil {
+			// The SDK only fails the request itself for unknown tools and
+			// malformed requests; tool failures are reported in the result.
+			tool = unknownTool
+		}
+		m.toolCalls.WithLabelValues(tool).Inc()
+		m.toolDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+		if r, ok := res.(*mcp.CallToolResult); err != nil || (ok && r != nil && r.IsError) {
+			m.toolErrors.WithLabelValues(tool).Inc()
+		}
+		return res, err
+	}
+}
+
+// GoogleClientOptions returns options that count the requests made by gRPC
+// based Google Cloud clients. They have no effect on REST based clients, which
+// are counted by WrapGoogleRESTTransport.
+func (m *Metrics) GoogleClientOptions() []option.ClientOption {
+	return []option.ClientOption{
+		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(m.unaryInterceptor)),
+		option.WithGRPCDialOption(grpc.WithChainStreamInterceptor(m.streamInterceptor)),
+	}
+}
+
+func (m *Metrics) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	m.observeGRPC(method, err)
+	return err
+}
+
+func (m *Metrics) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	m.observeGRPC(method, err)
+	return stream, err
+}
+
+// observeGRPC records a call to a full gRPC method name such as
+// "/google.container.v1.ClusterManager/ListClusters".
+func (m *Metrics) observeGRPC(fullMethod string, err error) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		service, method = "", fullMethod
+	}
+	m.outboundRequests.WithLabelValues(targetGoogleCloud, service, method, status.Code(err).String()).Inc()
+}
+
+// WrapGoogleRESTTransport wraps rt to count the requests of REST based Google
+// Cloud clients, such as Cloud Trace, by API host and HTTP method.
+func (m *Metrics) WrapGoogleRESTTransport(rt http.RoundTripper) http.RoundTripper {
+	return &roundTripper{wrapped: rt, m: m, target: targetGoogleCloud}
+}
+
+// WrapKubernetesTransport wraps rt to count Kubernetes API requests. It is
+// suitable for use with rest.Config.Wrap.
+func (m *Metrics) WrapKubernetesTransport(rt http.RoundTripper) http.RoundTripper {
+	return &roundTripper{wrapped: rt, m: m, target: targetKubernetes}
+}
+
+type roundTripper struct {
+	wrapped http.RoundTripper
+	m       *Metrics
+	target  string
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.wrapped.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	t.m.outboundRequests.WithLabelValues(t.target, req.URL.Host, req.Method, code).Inc()
+	return resp, err
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scrape returns the metrics exposition served by m.
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("metrics handler returned status %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func assertContains(t *testing.T, exposition string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(exposition, line+"\n") {
+			t.Errorf("metrics do not contain %q", line)
+		}
+	}
+}
+
+type echoArgs struct {
+	Fail bool `json:"fail"`
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := New()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(m.Middleware)
+	m.ObserveSessions(server)
+	mcp.AddTool(server, &mcp.Tool{Name: "echo"}, func(_ context.Context, _ *mcp.CallToolRequest, args echoArgs) (*mcp.CallToolResult, any, error) {
+		if args.Fail {
+			return nil, nil, errors.New("failed")
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil, nil
+	})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = server.Run(ctx, serverTransport)
+	}()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	for _, fail := range []bool{false, false, true} {
+		if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{"fail": fail}}); err != nil {
+			t.Fatalf("CallTool() failed: %v", err)
+		}
+	}
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "does-not-exist"}); err == nil {
+		t.Fatal("CallTool() for an unknown tool succeeded, want error")
+	}
+
+	assertContains(t, scrape(t, m),
+		`gke_mcp_tool_calls_total{tool="echo"} 3`,
+		`gke_mcp_tool_errors_total{tool="echo"} 1`,
+		`gke_mcp_tool_call_duration_seconds_count{tool="echo"} 3`,
+		`gke_mcp_tool_calls_total{tool="unknown"} 1`,
+		`gke_mcp_tool_errors_total{tool="unknown"} 1`,
+		`gke_mcp_active_sessions 1`,
+	)
+}
+
+func TestWrapKubernetesTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	m := New()
+	client := &http.Client{Transport: m.WrapKubernetesTransport(http.DefaultTransport)}
+	for _, path := range []string{"/api", "/api", "/apis/missing"} {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	assertContains(t, scrape(t, m),
+		`gke_mcp_outbound_requests_total{code="200",method="GET",service="`+host+`",target="kubernetes"} 2`,
+		`gke_mcp_outbound_requests_total{code="404",method="GET",service="`+host+`",target="kubernetes"} 1`,
+	)
+}
+
+func TestWrapGoogleRESTTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	m := New()
+	client := &http.Client{Transport: m.WrapGoogleRESTTransport(http.DefaultTransport)}
+	resp, err := client.Get(srv.URL + "/v1/projects/p/traces")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	assertContains(t, scrape(t, m),
+		`gke_mcp_outbound_requests_total{code="403",method="GET",service="`+host+`",target="gcp"} 1`,
+	)
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	m := New()
+	invoke := func(err error) grpc.UnaryInvoker {
+		return func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			return err
+		}
+	}
+	const method = "/google.container.v1.ClusterManager/ListClusters"
+	_ = m.unaryInterceptor(context.Background(), method, nil, nil, nil, invoke(nil))
+	if err := m.unaryInterceptor(context.Background(), method, nil, nil, nil, invoke(status.Error(codes.PermissionDenied, "denied"))); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("interceptor returned %v, want the invoker's error", err)
+	}
+
+	assertContains(t, scrape(t, m),
+		`gke_mcp_outbound_requests_total{code="OK",method="ListClusters",service="google.container.v1.ClusterManager",target="gcp"} 1`,
+		`gke_mcp_outbound_requests_total{code="PermissionDenied",method="ListClusters",service="google.container.v1.ClusterManager",target="gcp"} 1`,
+	)
+}
@@ -0,0 +1,335 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package serverlog writes the server's log to stderr and forwards it to the
+// MCP clients that ask for it with logging/setLevel.
+package serverlog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// loggerName is the logger reported in notifications/message.
+const loggerName = "gke-mcp"
+
+// Handler is a slog.Handler that writes records to a writer in the format of
+// the log package, and sends them as notifications/message to MCP sessions of
+// the server it forwards to. A record logged while handling a request only
+// goes to the session of that request, and a record logged with a context
+// from ServerWide goes to every session. Other records, such as those of the
+// log package, are only written. Each session only receives the records at or
+// above the level its client set.
+type Handler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	level  slog.Leveler
+	server *atomic.Pointer[mcp.Server]
+	levels *sessionLevels
+	// attrs are the formatted attributes added with WithAttrs.
+	attrs  string
+	prefix string
+}
+
+// NewHandler returns a Handler that writes records at or above level to w.
+func NewHandler(w io.Writer, level slog.Leveler) *Handler {
+	return &Handler{
+		w:      w,
+		mu:     &sync.Mutex{},
+		level:  level,
+		server: &atomic.Pointer[mcp.Server]{},
+		levels: &sessionLevels{levels: make(map[*mcp.ServerSession]slog.Level)},
+	}
+}
+
+// Forward starts sending records to the sessions of s.
+func (h *Handler) Forward(s *mcp.Server) {
+	s.AddReceivingMiddleware(h.middleware)
+	h.server.Store(s)
+}
+
+// middleware passes the session of each request on to the records logged
+// while handling it, and keeps track of the level each client sets.
+func (h *Handler) middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		ss, ok := req.GetSession().(*mcp.ServerSession)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		if level, ok := requestLevel(req.GetParams()); ok {
+			h.levels.set(ss, level)
+		}
+		return next(context.WithValue(ctx, sessionKey{}, ss), method, req)
+	}
+}
+
+// requestLevel returns the logging level that the client sets with the params
+// of a request: logging/setLevel sets it for the session, and newer protocol
+// versions for each request.
+func requestLevel(p mcp.Params) (mcp.LoggingLevel, bool) { //nolint:staticcheck
+	if p, ok := p.(*mcp.SetLoggingLevelParams); ok && p != nil { //nolint:staticcheck
+		return p.Level, true
+	}
+	if p == nil || reflect.ValueOf(p).IsNil() {
+		return "", false
+	}
+	level, ok := p.GetMeta()[mcp.MetaKeyLogLevel].(string)
+	return mcp.LoggingLevel(level), ok //nolint:staticcheck
+}
+
+type (
+	sessionKey    struct{}
+	serverWideKey struct{}
+)
+
+// ServerWide returns a context for records about the whole server, such as
+// credential checks and shutdown, which are sent to every session.
+func ServerWide(ctx context.Context) context.Context {
+	return context.WithValue(ctx, serverWideKey{}, true)
+}
+
+func sessionFromContext(ctx context.Context) *mcp.ServerSession {
+	ss, _ := ctx.Value(sessionKey{}).(*mcp.ServerSession)
+	return ss
+}
+
+// Enabled implements slog.Handler. Records below the level of the writer are
+// still handled if a session they would be sent to asked for them.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if level >= h.level.Level() {
+		return true
+	}
+	if h.server.Load() == nil {
+		return false
+	}
+	if ss := sessionFromContext(ctx); ss != nil {
+		l, ok := h.levels.get(ss)
+		return ok && level >= l
+	}
+	if ctx.Value(serverWideKey{}) != nil {
+		l, ok := h.levels.min()
+		return ok && level >= l
+	}
+	return false
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, h.prefix, a)
+		return true
+	})
+	msg := b.String()
+
+	if r.Level >= h.level.Level() {
+		t := r.Time
+		if t.IsZero() {
+			t = time.Now()
+		}
+		h.mu.Lock()
+		_, err := fmt.Fprintf(h.w, "%s %s\n", t.Format("2006/01/02 15:04:05"), msg)
+		h.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	s := h.server.Load()
+	if s == nil {
+		return nil
+	}
+	// Sessions drop records below the level their client set, and all
+	// records until it sets one. The logging feature is deprecated by newer
+	// protocol versions, but remains the only way to reach the client's log.
+	params := &mcp.LoggingMessageParams{ //nolint:staticcheck
+		Level:  mcpLevel(r.Level),
+		Logger: loggerName,
+		Data:   msg,
+	}
+	// Failures are not logged, as that would forward them again.
+	if ss := sessionFromContext(ctx); ss != nil {
+		_ = ss.Log(context.WithoutCancel(ctx), params) //nolint:staticcheck
+		return nil
+	}
+	if ctx.Value(serverWideKey{}) == nil {
+		return nil
+	}
+	for ss := range s.Sessions() {
+		_ = ss.Log(context.WithoutCancel(ctx), params) //nolint:staticcheck
+	}
+	return nil
+}
+
+// sessionLevels are the lowest levels of the records that the clients of
+// sessions asked for.
+type sessionLevels struct {
+	mu     sync.Mutex
+	levels map[*mcp.ServerSession]slog.Level
+}
+
+func (l *sessionLevels) get(ss *mcp.ServerSession) (slog.Level, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	level, ok := l.levels[ss]
+	return level, ok
+}
+
+// min returns the lowest level of any session.
+func (l *sessionLevels) min() (slog.Level, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var lowest slog.Level
+	found := false
+	for _, level := range l.levels {
+		if !found || level < lowest {
+			lowest, found = level, true
+		}
+	}
+	return lowest, found
+}
+
+// set records the level that the client of ss set, until ss ends.
+func (l *sessionLevels) set(ss *mcp.ServerSession, mcpLevel mcp.LoggingLevel) { //nolint:staticcheck
+	level, ok := slogLevel(mcpLevel)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, tracked := l.levels[ss]
+	if !ok {
+		// No record is sent at the levels above error.
+		delete(l.levels, ss)
+		return
+	}
+	l.levels[ss] = level
+	if !tracked {
+		go func() {
+			_ = ss.Wait()
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			delete(l.levels, ss)
+		}()
+	}
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, a := range attrs {
+		writeAttr(&b, h.prefix, a)
+	}
+	h2.attrs = b.String()
+	return &h2
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func writeAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			writeAttr(b, prefix, ga)
+		}
+		return
+	}
+	fmt.Fprintf(b, " %s%s=%v", prefix, a.Key, a.Value)
+}
+
+// mcpLevel returns the MCP logging level of a slog level.
+func mcpLevel(l slog.Level) mcp.LoggingLevel { //nolint:staticcheck
+	switch {
+	case l >= slog.LevelError:
+		return "error"
+	case l >= slog.LevelWarn:
+		return "warning"
+	case l >= slog.LevelInfo:
+		return "info"
+	default:
+		return "debug"
+	}
+}
+
+// slogLevel returns the slog level of the records that a session at an MCP
+// logging level receives, and false if it receives none.
+func slogLevel(l mcp.LoggingLevel) (slog.Level, bool) { //nolint:staticcheck
+	switch l {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "notice", "warning":
+		// Records are never sent at notice level.
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
+
+// Debugf logs a message at debug level to the default slog logger. The
+// message goes to the session of the request that ctx belongs to, if any.
+func Debugf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelDebug, format, args...)
+}
+
+// Infof logs a message at info level to the default slog logger.
+func Infof(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelInfo, format, args...)
+}
+
+// Warningf logs a message at warning level to the default slog logger.
+func Warningf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelWarn, format, args...)
+}
+
+// Errorf logs a message at error level to the default slog logger.
+func Errorf(ctx context.Context, format string, args ...any) {
+	logf(ctx, slog.LevelError, format, args...)
+}
+
+func logf(ctx context.Context, level slog.Level, format string, args ...any) {
+	l := slog.Default()
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	// Messages keep no trailing newline, as log.Printf callers often add one.
+	l.Log(ctx, level, strings.TrimSuffix(fmt.Sprintf(format, args...), "\n"))
+}
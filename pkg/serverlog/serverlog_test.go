@@ -0,0 +1,238 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestHandlerWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, slog.LevelInfo))
+
+	logger.Debug("hidden")
+	logger.Info("cluster ready", "name", "prod")
+	logger.With("tool", "get_cluster").WithGroup("req").Warn("slow call", "ms", 1200)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []string{
+		`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} cluster ready name=prod$`,
+		`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} slow call tool=get_cluster req.ms=1200$`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i, re := range want {
+		if !regexp.MustCompile(re).MatchString(lines[i]) {
+			t.Errorf("line %d = %q, want match for %s", i, lines[i], re)
+		}
+	}
+}
+
+func TestMCPLevel(t *testing.T) {
+	tests := map[slog.Level]mcp.LoggingLevel{ //nolint:staticcheck
+		slog.LevelDebug:     "debug",
+		slog.LevelInfo:      "info",
+		slog.LevelWarn:      "warning",
+		slog.LevelError:     "error",
+		slog.LevelError + 4: "error",
+	}
+	for level, want := range tests {
+		if got := mcpLevel(level); got != want {
+			t.Errorf("mcpLevel(%v) = %q, want %q", level, got, want)
+		}
+	}
+}
+
+func TestForward(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(&buf, slog.LevelInfo)
+	logger := slog.New(h)
+	ctx := context.Background()
+	serverCtx := ServerWide(ctx)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, &mcp.ServerOptions{
+		Capabilities: &mcp.ServerCapabilities{Logging: &mcp.LoggingCapabilities{}}, //nolint:staticcheck
+	})
+	h.Forward(server)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	messages := make(chan *mcp.LoggingMessageParams, 10) //nolint:staticcheck
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		LoggingMessageHandler: func(_ context.Context, req *mcp.LoggingMessageRequest) { //nolint:staticcheck
+			messages <- req.Params
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	// Nothing is sent before the client sets a level.
+	logger.ErrorContext(serverCtx, "before setLevel")
+	if err := session.SetLoggingLevel(ctx, &mcp.SetLoggingLevelParams{Level: "warning"}); err != nil { //nolint:staticcheck
+		t.Fatalf("SetLoggingLevel() failed: %v", err)
+	}
+	logger.DebugContext(serverCtx, "agent trace")
+	logger.InfoContext(serverCtx, "apps installed")
+	logger.WarnContext(serverCtx, "failed to close client", "err", "closed")
+	// Records of no request nor of the whole server are not sent.
+	logger.Error("request of another session")
+
+	select {
+	case got := <-messages:
+		if got.Level != "warning" || got.Logger != loggerName || got.Data != "failed to close client err=closed" {
+			t.Errorf("notifications/message = %+v, want the warning", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notifications/message for the warning")
+	}
+	select {
+	case got := <-messages:
+		t.Errorf("unexpected notifications/message %+v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Debug records reach clients that ask for them, but not the writer.
+	if err := session.SetLoggingLevel(ctx, &mcp.SetLoggingLevelParams{Level: "debug"}); err != nil { //nolint:staticcheck
+		t.Fatalf("SetLoggingLevel() failed: %v", err)
+	}
+	logger.DebugContext(serverCtx, "agent trace")
+	select {
+	case got := <-messages:
+		if got.Level != "debug" || got.Data != "agent trace" {
+			t.Errorf("notifications/message = %+v, want the debug trace", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notifications/message for the debug trace")
+	}
+	if strings.Contains(buf.String(), "agent trace") {
+		t.Errorf("writer got debug record:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "before setLevel") {
+		t.Errorf("writer is missing a record:\n%s", buf.String())
+	}
+}
+
+// connectSession connects a client that sets level to server, and returns the
+// session and a channel of the messages it receives.
+func connectSession(t *testing.T, server *mcp.Server, level mcp.LoggingLevel) (*mcp.ClientSession, <-chan string) { //nolint:staticcheck
+	t.Helper()
+	ctx := context.Background()
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	messages := make(chan string, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		LoggingMessageHandler: func(_ context.Context, req *mcp.LoggingMessageRequest) { //nolint:staticcheck
+			messages <- req.Params.Data.(string)
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	if err := session.SetLoggingLevel(ctx, &mcp.SetLoggingLevelParams{Level: level}); err != nil { //nolint:staticcheck
+		t.Fatalf("SetLoggingLevel() failed: %v", err)
+	}
+	return session, messages
+}
+
+func TestForwardToRequestSession(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(&buf, slog.LevelInfo)
+	slog.SetDefault(slog.New(h))
+	t.Cleanup(func() { slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil))) })
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, &mcp.ServerOptions{
+		Capabilities: &mcp.ServerCapabilities{Logging: &mcp.LoggingCapabilities{}}, //nolint:staticcheck
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "trace"}, func(ctx context.Context, _ *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+		Debugf(ctx, "trace of the caller")
+		return &mcp.CallToolResult{}, nil, nil
+	})
+	h.Forward(server)
+	caller, callerMessages := connectSession(t, server, "debug")
+	_, otherMessages := connectSession(t, server, "debug")
+
+	// Clients of newer protocol versions set the level of each request.
+	params := &mcp.CallToolParams{Name: "trace", Meta: mcp.Meta{mcp.MetaKeyLogLevel: "debug"}}
+	if _, err := caller.CallTool(context.Background(), params); err != nil {
+		t.Fatalf("CallTool() failed: %v", err)
+	}
+	select {
+	case got := <-callerMessages:
+		if got != "trace of the caller" {
+			t.Errorf("caller got notifications/message %q, want the trace", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notifications/message for the caller")
+	}
+	select {
+	case got := <-otherMessages:
+		t.Errorf("other session got notifications/message %q", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	h := NewHandler(io.Discard, slog.LevelInfo)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, &mcp.ServerOptions{
+		Capabilities: &mcp.ServerCapabilities{Logging: &mcp.LoggingCapabilities{}}, //nolint:staticcheck
+	})
+	h.Forward(server)
+	serverCtx := ServerWide(context.Background())
+	if h.Enabled(serverCtx, slog.LevelDebug) {
+		t.Error("Enabled(debug) = true before any session set a level")
+	}
+	connectSession(t, server, "warning")
+	if h.Enabled(serverCtx, slog.LevelDebug) {
+		t.Error("Enabled(debug) = true with a session at warning")
+	}
+	session, _ := connectSession(t, server, "debug")
+	if !h.Enabled(serverCtx, slog.LevelDebug) {
+		t.Error("Enabled(debug) = false with a session at debug")
+	}
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Enabled(debug) = true for a record of no session")
+	}
+	_ = session.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for h.Enabled(serverCtx, slog.LevelDebug) {
+		if time.Now().After(deadline) {
+			t.Fatal("Enabled(debug) = true after the session at debug ended")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tlsconfig builds the TLS configuration for the HTTP server mode and
+// reloads certificates when they change on disk.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+)
+
+// Options configures the server certificate and optional client verification.
+type Options struct {
+	// CertFile is the path to the PEM encoded server certificate chain.
+	CertFile string
+	// KeyFile is the path to the PEM encoded server private key.
+	KeyFile string
+	// ClientCAFile is the path to a PEM bundle of CAs used to verify client
+	// certificates. If set, clients must present a certificate signed by one of them.
+	ClientCAFile string
+}
+
+// Enabled returns true if TLS was requested.
+func (o Options) Enabled() bool {
+	return o.CertFile != "" || o.KeyFile != "" || o.ClientCAFile != ""
+}
+
+// fileStamp identifies a version of a file on disk.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Reloader serves TLS certificates loaded from disk and reloads them when the
+// underlying files change, so certificates can be rotated without restarting
+// the server or dropping established sessions.
+type Reloader struct {
+	opts Options
+
+	mu        sync.Mutex
+	stamps    map[string]fileStamp
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	// failedStamps are the files as of the last failed reload, which is not
+	// retried until they change again.
+	failedStamps map[string]fileStamp
+}
+
+// NewReloader loads the files named in opts and returns a Reloader serving them.
+func NewReloader(opts Options) (*Reloader, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, fmt.Errorf("both a TLS certificate and key are required")
+	}
+	r := &Reloader{opts: opts}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig returns a server TLS configuration backed by the Reloader.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.getConfigForClient,
+	}
+}
+
+func (r *Reloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stamps := r.currentStamps(); !maps.Equal(stamps, r.stamps) && !maps.Equal(stamps, r.failedStamps) {
+		if err := r.load(); err != nil {
+			// Keep serving the previous certificates; the files may be mid-rotation.
+			r.failedStamps = stamps
+			serverlog.Warningf(serverlog.ServerWide(context.Background()), "Failed to reload TLS certificates, continuing with previous ones: %v", err)
+		} else {
+			r.failedStamps = nil
+			serverlog.Infof(serverlog.ServerWide(context.Background()), "Reloaded TLS certificates from %s", r.opts.CertFile)
+		}
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if r.clientCAs != nil {
+		cfg.ClientCAs = r.clientCAs
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// currentStamps returns the stamps of the files that exist.
+func (r *Reloader) currentStamps() map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	for _, path := range r.files() {
+		if st, err := stat(path); err == nil {
+			stamps[path] = st
+		}
+	}
+	return stamps
+}
+
+// load reads all files from disk. It must be called with r.mu held unless the
+// Reloader has not been shared yet. On error the previous state is left intact.
+func (r *Reloader) load() error {
+	stamps := make(map[string]fileStamp)
+	for _, path := range r.files() {
+		st, err := stat(path)
+		if err != nil {
+			return err
+		}
+		stamps[path] = st
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.opts.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.opts.ClientCAFile)
+		}
+	}
+
+	r.stamps = stamps
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	return nil
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.opts.CertFile, r.opts.KeyFile}
+	if r.opts.ClientCAFile != "" {
+		files = append(files, r.opts.ClientCAFile)
+	}
+	return files
+}
+
+func stat(path string) (fileStamp, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: fi.ModTime(), size: fi.Size()}, nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsconfig
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("failed to generate serial: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	// Set the modification time explicitly so rewrites within the filesystem's
+	// timestamp granularity are still detected.
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to set modification time of %s: %v", path, err)
+	}
+}
+
+func startServer(t *testing.T, r *Reloader) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = r.TLSConfig()
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// serverCommonName connects to the server and returns the CN of the certificate it presents.
+func serverCommonName(t *testing.T, url string, client *http.Client) (string, error) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return resp.TLS.PeerCertificates[0].Subject.CommonName, nil
+}
+
+func TestReloader_ReloadsServerCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "server-ca")
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	now := time.Now()
+	certPEM, keyPEM := ca.issue(t, "server-v1", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, now)
+	writeFile(t, keyFile, keyPEM, now)
+
+	r, err := NewReloader(Options{CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("NewReloader() failed: %v", err)
+	}
+	srv := startServer(t, r)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	newClient := func() *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+	}
+
+	if cn, err := serverCommonName(t, srv.URL, newClient()); err != nil || cn != "server-v1" {
+		t.Fatalf("got certificate %q (err: %v), want server-v1", cn, err)
+	}
+
+	// Rotate the certificate on disk.
+	certPEM, keyPEM = ca.issue(t, "server-v2", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, now.Add(time.Minute))
+	writeFile(t, keyFile, keyPEM, now.Add(time.Minute))
+
+	if cn, err := serverCommonName(t, srv.URL, newClient()); err != nil || cn != "server-v2" {
+		t.Fatalf("got certificate %q (err: %v) after rotation, want server-v2", cn, err)
+	}
+
+	// A broken rotation keeps the last good certificate.
+	writeFile(t, keyFile, []byte("not a key"), now.Add(2*time.Minute))
+	if cn, err := serverCommonName(t, srv.URL, newClient()); err != nil || cn != "server-v2" {
+		t.Fatalf("got certificate %q (err: %v) after failed rotation, want server-v2", cn, err)
+	}
+}
+
+func TestReloader_LogsFailedReloadOnce(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "server-ca")
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	now := time.Now()
+	certPEM, keyPEM := ca.issue(t, "server-v1", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, now)
+	writeFile(t, keyFile, keyPEM, now)
+	r, err := NewReloader(Options{CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("NewReloader() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	handshakes := func() {
+		for range 3 {
+			if _, err := r.getConfigForClient(nil); err != nil {
+				t.Fatalf("getConfigForClient() failed: %v", err)
+			}
+		}
+	}
+
+	writeFile(t, keyFile, []byte("not a key"), now.Add(time.Minute))
+	handshakes()
+	if got := strings.Count(buf.String(), "Failed to reload"); got != 1 {
+		t.Errorf("logged %d failed reloads of the same files, want 1:\n%s", got, buf.String())
+	}
+
+	// Each new version of the files is tried again.
+	writeFile(t, keyFile, []byte("still not a key"), now.Add(2*time.Minute))
+	handshakes()
+	if got := strings.Count(buf.String(), "Failed to reload"); got != 2 {
+		t.Errorf("logged %d failed reloads, want 2:\n%s", got, buf.String())
+	}
+
+	writeFile(t, keyFile, keyPEM, now.Add(3*time.Minute))
+	handshakes()
+	if got := strings.Count(buf.String(), "Reloaded TLS certificates"); got != 1 {
+		t.Errorf("logged %d reloads after the files were fixed, want 1:\n%s", got, buf.String())
+	}
+}
+
+func TestReloader_RequiresClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newTestCA(t, "server-ca")
+	clientCA := newTestCA(t, "client-ca")
+	otherCA := newTestCA(t, "other-ca")
+
+	now := time.Now()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "client-ca.crt")
+	certPEM, keyPEM := serverCA.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, now)
+	writeFile(t, keyFile, keyPEM, now)
+	writeFile(t, caFile, clientCA.pem, now)
+
+	r, err := NewReloader(Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+	if err != nil {
+		t.Fatalf("NewReloader() failed: %v", err)
+	}
+	srv := startServer(t, r)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCA.cert)
+	clientWithCert := func(ca *testCA) *http.Client {
+		cfg := &tls.Config{RootCAs: roots}
+		if ca != nil {
+			certPEM, keyPEM := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				t.Fatalf("failed to load client key pair: %v", err)
+			}
+			cfg.Certificates = []tls.Certificate{cert}
+		}
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	}
+
+	if _, err := serverCommonName(t, srv.URL, clientWithCert(nil)); err == nil {
+		t.Error("request without a client certificate succeeded, want error")
+	}
+	if _, err := serverCommonName(t, srv.URL, clientWithCert(otherCA)); err == nil {
+		t.Error("request with an untrusted client certificate succeeded, want error")
+	}
+	if _, err := serverCommonName(t, srv.URL, clientWithCert(clientCA)); err != nil {
+		t.Errorf("request with a trusted client certificate failed: %v", err)
+	}
+
+	// Rotating the client CA bundle takes effect for new connections.
+	writeFile(t, caFile, otherCA.pem, now.Add(time.Minute))
+	if _, err := serverCommonName(t, srv.URL, clientWithCert(otherCA)); err != nil {
+		t.Errorf("request with a certificate from the rotated CA failed: %v", err)
+	}
+	if _, err := serverCommonName(t, srv.URL, clientWithCert(clientCA)); err == nil {
+		t.Error("request with a certificate from the old CA succeeded, want error")
+	}
+}
+
+func TestNewReloader_Errors(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "ca")
+	certPEM, keyPEM := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	badCAFile := filepath.Join(dir, "bad-ca.crt")
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+	writeFile(t, badCAFile, []byte("garbage"), time.Now())
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "missing key", opts: Options{CertFile: certFile}},
+		{name: "client CA without certificate", opts: Options{ClientCAFile: badCAFile}},
+		{name: "nonexistent certificate", opts: Options{CertFile: filepath.Join(dir, "missing"), KeyFile: keyFile}},
+		{name: "mismatched key", opts: Options{CertFile: keyFile, KeyFile: certFile}},
+		{name: "invalid client CA", opts: Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: badCAFile}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewReloader(tc.opts); err == nil {
+				t.Error("NewReloader() succeeded, want error")
+			}
+		})
+	}
+}
@@ -17,7 +17,6 @@ package cluster
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -31,6 +30,7 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	containerpb "cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/confirm"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/k8s"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/params"
 	"github.com/googleapis/gax-go/v2/callctx"
@@ -106,21 +106,25 @@ type getClustersArgs struct {
 type createClustersArgs struct {
 	params.LocationRequired
 	Cluster string `json:"cluster" jsonschema:"Required. A cluster resource represented as a string using JSON format."`
+	waitOption
 }
 
 type updateClusterArgs struct {
 	params.Cluster
 	Update string `json:"update" jsonschema:"Required. A description of the update represented as a string using JSON format."`
+	waitOption
 }
 
 type deleteClusterArgs struct {
 	params.Cluster
 	DeletionPolicy string `json:"deletionPolicy,omitempty" jsonschema:"Optional. The deletion policy to apply to the request. Options: 'VERIFY_UNUSED' (default: checks for active compute, external exposure, and persistent data before deletion), 'FORCE' (immediately deletes the cluster without safety checks)."`
+	waitOption
 }
 
 // getKubeconfigArgs defines arguments for getting a GKE cluster's kubeconfig.
 type getKubeconfigArgs struct {
 	params.Cluster
+	Endpoint string `json:"endpoint,omitempty" jsonschema:"Optional. The control plane endpoint to connect through: 'public' (external IP), 'private' (internal IP, only reachable from the cluster's VPC network), 'dns' (DNS-based endpoint), 'connect-gateway' (Fleet Connect Gateway of the cluster's fleet membership) or 'auto'. Defaults to the server's --cluster-endpoint flag, 'auto' unless set, which picks the public, DNS-based or private endpoint in that order."`
 }
 
 type getNodeSosReportArgs struct {
@@ -131,7 +135,64 @@ type getNodeSosReportArgs struct {
 	TimeoutSeconds int    `json:"timeout,omitempty" jsonschema:"Timeout in seconds for the report collection (applies to both pod and ssh methods). Defaults to 180 (3 minutes)."`
 }
 
-func (h *handlers) listClusters(ctx context.Context, _ *mcp.CallToolRequest, args *listClustersArgs) (*mcp.CallToolResult, any, error) {
+// listClustersOutput is the structured output of list_clusters.
+type listClustersOutput struct {
+	Clusters     []*clusterOutput `json:"clusters" jsonschema:"The clusters found."`
+	MissingZones []string         `json:"missingZones,omitempty" jsonschema:"Locations that could not be reached, whose clusters are missing from the list."`
+}
+
+// clusterOutput is the structured output of get_cluster, and of each cluster
+// in list_clusters. Fields outside the read mask are omitted.
+type clusterOutput struct {
+	Name                 string            `json:"name" jsonschema:"The cluster name."`
+	Location             string            `json:"location,omitempty" jsonschema:"The region or zone of the cluster."`
+	Description          string            `json:"description,omitempty"`
+	Status               string            `json:"status,omitempty" jsonschema:"The cluster status, e.g. RUNNING, PROVISIONING, RECONCILING, ERROR."`
+	Conditions           []string          `json:"conditions,omitempty" jsonschema:"Messages describing why the cluster is in its current status."`
+	Autopilot            bool              `json:"autopilot,omitempty" jsonschema:"Whether the cluster is an Autopilot cluster."`
+	ReleaseChannel       string            `json:"releaseChannel,omitempty" jsonschema:"The release channel, e.g. RAPID, REGULAR, STABLE, EXTENDED."`
+	CurrentMasterVersion string            `json:"currentMasterVersion,omitempty" jsonschema:"The GKE version of the control plane."`
+	Endpoint             string            `json:"endpoint,omitempty" jsonschema:"The IP address of the control plane."`
+	Network              string            `json:"network,omitempty"`
+	Subnetwork           string            `json:"subnetwork,omitempty"`
+	FleetMembership      string            `json:"fleetMembership,omitempty" jsonschema:"The fleet membership of the cluster, if it is registered to a fleet."`
+	ResourceLabels       map[string]string `json:"resourceLabels,omitempty"`
+	CreateTime           string            `json:"createTime,omitempty" jsonschema:"When the cluster was created, in RFC3339 format."`
+	SelfLink             string            `json:"selfLink,omitempty"`
+	NodePools            []*nodePoolOutput `json:"nodePools,omitempty" jsonschema:"The node pools of the cluster."`
+}
+
+func newClusterOutput(c *containerpb.Cluster) *clusterOutput {
+	out := &clusterOutput{
+		Name:                 c.GetName(),
+		Location:             c.GetLocation(),
+		Description:          c.GetDescription(),
+		Autopilot:            c.GetAutopilot().GetEnabled(),
+		CurrentMasterVersion: c.GetCurrentMasterVersion(),
+		Endpoint:             c.GetEndpoint(),
+		Network:              c.GetNetwork(),
+		Subnetwork:           c.GetSubnetwork(),
+		FleetMembership:      c.GetFleet().GetMembership(),
+		ResourceLabels:       c.GetResourceLabels(),
+		CreateTime:           c.GetCreateTime(),
+		SelfLink:             c.GetSelfLink(),
+	}
+	if c.GetStatus() != containerpb.Cluster_STATUS_UNSPECIFIED {
+		out.Status = c.GetStatus().String()
+	}
+	for _, cond := range c.GetConditions() {
+		out.Conditions = append(out.Conditions, cond.GetMessage())
+	}
+	if ch := c.GetReleaseChannel().GetChannel(); ch != containerpb.ReleaseChannel_UNSPECIFIED {
+		out.ReleaseChannel = ch.String()
+	}
+	for _, np := range c.GetNodePools() {
+		out.NodePools = append(out.NodePools, newNodePoolOutput(np))
+	}
+	return out
+}
+
+func (h *handlers) listClusters(ctx context.Context, _ *mcp.CallToolRequest, args *listClustersArgs) (*mcp.CallToolResult, *listClustersOutput, error) {
 	ctx = callctx.SetHeaders(ctx,
 		callctx.XGoogFieldMaskHeader,
 		getFieldMask(args.ReadMask, listClustersDefaultFieldMask))
@@ -146,15 +207,23 @@ func (h *handlers) listClusters(ctx context.Context, _ *mcp.CallToolRequest, arg
 
 	header := fmt.Sprintf("Found %d clusters:", len(resp.Clusters))
 
+	out := &listClustersOutput{
+		Clusters:     make([]*clusterOutput, 0, len(resp.GetClusters())),
+		MissingZones: resp.GetMissingZones(),
+	}
+	for _, c := range resp.GetClusters() {
+		out.Clusters = append(out.Clusters, newClusterOutput(c))
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: header},
 			&mcp.TextContent{Text: protojson.Format(resp)},
 		},
-	}, nil, nil
+	}, out, nil
 }
 
-func (h *handlers) getCluster(ctx context.Context, _ *mcp.CallToolRequest, args *getClustersArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) getCluster(ctx context.Context, _ *mcp.CallToolRequest, args *getClustersArgs) (*mcp.CallToolResult, *clusterOutput, error) {
 	ctx = callctx.SetHeaders(ctx,
 		callctx.XGoogFieldMaskHeader,
 		getFieldMask(args.ReadMask, getClusterDefaultFieldMask))
@@ -171,10 +240,10 @@ func (h *handlers) getCluster(ctx context.Context, _ *mcp.CallToolRequest, args
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: protojson.Format(resp)},
 		},
-	}, nil, nil
+	}, newClusterOutput(resp), nil
 }
 
-func (h *handlers) createCluster(ctx context.Context, _ *mcp.CallToolRequest, args *createClustersArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) createCluster(ctx context.Context, toolReq *mcp.CallToolRequest, args *createClustersArgs) (*mcp.CallToolResult, any, error) {
 	var clusterObj containerpb.Cluster
 	if err := protojson.Unmarshal([]byte(args.Cluster), &clusterObj); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal cluster JSON: %w", err)
@@ -189,37 +258,31 @@ func (h *handlers) createCluster(ctx context.Context, _ *mcp.CallToolRequest, ar
 		return nil, nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: protojson.Format(resp)},
-		},
-	}, nil, nil
+	return h.operationResult(ctx, toolReq, args.LocationPath(), resp, args.Wait)
 }
 
 // getKubeconfig retrieves GKE cluster details and constructs a kubeconfig file.
 // It appends/updates the configuration in the user's ~/.kube/config file.
 func (h *handlers) getKubeconfig(ctx context.Context, _ *mcp.CallToolRequest, args *getKubeconfigArgs) (*mcp.CallToolResult, any, error) {
-	req := &containerpb.GetClusterRequest{
-		Name: args.ClusterPath(),
+	endpointName := args.Endpoint
+	if endpointName == "" && h.c != nil {
+		endpointName = h.c.ClusterEndpoint()
 	}
-	resp, err := h.cmClient.GetCluster(ctx, req)
+	endpointType, err := k8s.ParseEndpointType(endpointName)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get cluster %s: %w", args.ClusterPath(), err)
+		return nil, nil, err
 	}
 
-	clusterCaCertificate := resp.GetMasterAuth().GetClusterCaCertificate()
-	endpoint := resp.GetEndpoint()
-
-	if clusterCaCertificate == "" {
-		return nil, nil, fmt.Errorf("clusterCaCertificate not found for cluster %s", args.ClusterPath())
+	req := &containerpb.GetClusterRequest{
+		Name: args.ClusterPath(),
 	}
-	if endpoint == "" {
-		return nil, nil, fmt.Errorf("endpoint not found for cluster %s", args.ClusterPath())
+	resp, err := h.cmClient.GetCluster(callctx.SetHeaders(ctx, callctx.XGoogFieldMaskHeader, k8s.ClusterEndpointFieldMask), req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cluster %s: %w", args.ClusterPath(), err)
 	}
-
-	// Ensure the endpoint starts with "https://"
-	if !strings.HasPrefix(endpoint, "https://") {
-		endpoint = "https://" + endpoint
+	endpoint, err := k8s.ClusterEndpoint(resp, endpointType)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Standard naming convention for gcloud-generated kubeconfigs
@@ -227,21 +290,20 @@ func (h *handlers) getKubeconfig(ctx context.Context, _ *mcp.CallToolRequest, ar
 
 	// Initialize a Kubeconfig object
 	pathOptions := clientcmd.NewDefaultPathOptions()
+	if h.c != nil {
+		pathOptions.LoadingRules.ExplicitPath = h.c.Kubeconfig()
+	}
 	oldKubeconfig, err := pathOptions.GetStartingConfig()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get starting config: %w", err)
 	}
 	newKubeconfig := oldKubeconfig.DeepCopy()
 
-	// Create new cluster, context, and user entries
-	clusterCaCertificateByte, err := base64.RawStdEncoding.DecodeString(clusterCaCertificate)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to decode clusterCaCertificate: %w", err)
-	}
-
+	// Create new cluster, context, and user entries. The DNS-based endpoint
+	// and Connect Gateway use publicly trusted certificates.
 	newCluster := &k8sClientApi.Cluster{
-		CertificateAuthorityData: clusterCaCertificateByte,
-		Server:                   endpoint,
+		CertificateAuthorityData: endpoint.CAData,
+		Server:                   endpoint.Server,
 	}
 	newContext := &k8sClientApi.Context{
 		Cluster:  newClusterName,
@@ -271,7 +333,7 @@ func (h *handlers) getKubeconfig(ctx context.Context, _ *mcp.CallToolRequest, ar
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("Kubeconfig for cluster %s (Project: %s, Location: %s) successfully appended/updated in %s. Current context set to %s.", args.ClusterPath(), args.ProjectID, args.Location, pathOptions.GlobalFile, newClusterName)},
+			&mcp.TextContent{Text: fmt.Sprintf("Kubeconfig for cluster %s (Project: %s, Location: %s) successfully appended/updated in %s with the %s endpoint %s. Current context set to %s.", args.ClusterPath(), args.ProjectID, args.Location, pathOptions.GetDefaultFilename(), endpoint.Type, endpoint.Server, newClusterName)},
 		},
 	}, nil, nil
 }
@@ -526,11 +588,15 @@ func (h *handlers) getNodeSosReportWithSSH(ctx context.Context, args *getNodeSos
 	}, nil, nil
 }
 
-func (h *handlers) updateCluster(ctx context.Context, _ *mcp.CallToolRequest, args *updateClusterArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) updateCluster(ctx context.Context, toolReq *mcp.CallToolRequest, args *updateClusterArgs) (*mcp.CallToolResult, any, error) {
 	var updateObj containerpb.ClusterUpdate
 	if err := protojson.Unmarshal([]byte(args.Update), &updateObj); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal update JSON: %w", err)
 	}
+	msg := fmt.Sprintf("Update GKE cluster %s with the following changes?\n\n%s", args.ClusterPath(), protojson.Format(&updateObj))
+	if res, err := confirm.Request(toolReq, h.c, msg); res != nil || err != nil {
+		return res, nil, err
+	}
 
 	req := &containerpb.UpdateClusterRequest{
 		Name:   args.ClusterPath(),
@@ -541,15 +607,21 @@ func (h *handlers) updateCluster(ctx context.Context, _ *mcp.CallToolRequest, ar
 		return nil, nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: protojson.Format(resp)},
-		},
-	}, nil, nil
+	return h.operationResult(ctx, toolReq, args.LocationPath(), resp, args.Wait)
 }
 
-func (h *handlers) deleteCluster(ctx context.Context, _ *mcp.CallToolRequest, args *deleteClusterArgs) (*mcp.CallToolResult, any, error) {
-	if !strings.EqualFold(args.DeletionPolicy, "FORCE") {
+func (h *handlers) deleteCluster(ctx context.Context, toolReq *mcp.CallToolRequest, args *deleteClusterArgs) (*mcp.CallToolResult, any, error) {
+	force := strings.EqualFold(args.DeletionPolicy, "FORCE")
+	msg := fmt.Sprintf("Delete GKE cluster %s? The cluster, its node pools and every workload running on it will be permanently deleted.", args.ClusterPath())
+	if force {
+		msg += " The check for load balancers, ingresses and persistent volumes in use will be skipped (deletionPolicy FORCE)."
+	}
+	// Confirm first: the check scans the cluster's workloads, which would
+	// otherwise be repeated when the client calls again with the answer.
+	if res, err := confirm.Request(toolReq, h.c, msg); res != nil || err != nil {
+		return res, nil, err
+	}
+	if !force {
 		if err := h.verifyClusterUnused(ctx, args.ClusterPath()); err != nil {
 			return nil, nil, fmt.Errorf("cluster deletion blocked by safety check: %w. Ensure local kubeconfig is updated (via get_kubeconfig) or set deletionPolicy='FORCE' to override", err)
 		}
@@ -563,11 +635,7 @@ func (h *handlers) deleteCluster(ctx context.Context, _ *mcp.CallToolRequest, ar
 		return nil, nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: protojson.Format(resp)},
-		},
-	}, nil, nil
+	return h.operationResult(ctx, toolReq, args.LocationPath(), resp, args.Wait)
 }
 
 func (h *handlers) verifyClusterUnused(ctx context.Context, clusterPath string) error {
@@ -16,8 +16,18 @@ package cluster
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/protobuf/proto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,6 +39,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func TestListClustersArgs_Fields(t *testing.T) {
@@ -216,6 +227,8 @@ type mockK8sProvider struct {
 	dynamicClient   dynamic.Interface
 	discoveryClient discovery.DiscoveryInterface
 	err             error
+	// calls counts the clients requested.
+	calls atomic.Int32
 }
 
 func (m *mockK8sProvider) RESTConfig(_ context.Context, _ string) (*rest.Config, error) {
@@ -223,6 +236,7 @@ func (m *mockK8sProvider) RESTConfig(_ context.Context, _ string) (*rest.Config,
 }
 
 func (m *mockK8sProvider) DynamicClient(_ context.Context, _ string) (dynamic.Interface, error) {
+	m.calls.Add(1)
 	return m.dynamicClient, m.err
 }
 
@@ -231,6 +245,7 @@ func (m *mockK8sProvider) DynamicClientWithHeaders(_ context.Context, _ string,
 }
 
 func (m *mockK8sProvider) DiscoveryClient(_ context.Context, _ string) (discovery.DiscoveryInterface, error) {
+	m.calls.Add(1)
 	return m.discoveryClient, m.err
 }
 
@@ -390,3 +405,144 @@ func TestVerifyClusterUnused(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteClusterConfirmsBeforeCheck(t *testing.T) {
+	ctx := context.Background()
+	provider := &mockK8sProvider{err: errors.New("cluster unreachable")}
+	h := &handlers{c: config.NewTestConfig("p", "l", "vertex-ai", "gemini-2.5-pro"), k8sProvider: provider}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "delete_cluster"}, h.deleteCluster)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		ElicitationHandler: func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			if n := provider.calls.Load(); n != 0 {
+				t.Errorf("cluster was checked %d times before the user answered", n)
+			}
+			return &mcp.ElicitResult{Action: "decline"}, nil
+		},
+	})
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	defer func() { _ = serverSession.Close() }()
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() failed: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "delete_cluster", Arguments: map[string]any{"project_id": "p", "location": "l", "cluster_name": "c"}})
+	if err != nil {
+		t.Fatalf("CallTool() failed: %v", err)
+	}
+	if !res.IsError || !strings.Contains(res.Content[0].(*mcp.TextContent).Text, "did not confirm") {
+		t.Errorf("delete_cluster = %v, want the declined error", res.Content[0])
+	}
+	if n := provider.calls.Load(); n != 0 {
+		t.Errorf("cluster was checked %d times after the user declined, want 0", n)
+	}
+}
+
+func TestListClusters(t *testing.T) {
+	f := &fakeClusterManager{clusters: &containerpb.ListClustersResponse{
+		Clusters: []*containerpb.Cluster{{
+			Name:                 "c1",
+			Location:             "us-central1",
+			Status:               containerpb.Cluster_RUNNING,
+			Autopilot:            &containerpb.Autopilot{Enabled: true},
+			ReleaseChannel:       &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR},
+			CurrentMasterVersion: "1.33.1-gke.100",
+			Conditions:           []*containerpb.StatusCondition{{Message: "upgrading"}},
+			NodePools:            []*containerpb.NodePool{{Name: "default-pool", Status: containerpb.NodePool_RUNNING}},
+		}},
+	}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, f)}
+
+	args := &listClustersArgs{}
+	args.ProjectID = "p"
+	args.Location = "-"
+	res, out, err := h.listClusters(context.Background(), &mcp.CallToolRequest{}, args)
+	if err != nil {
+		t.Fatalf("listClusters() failed: %v", err)
+	}
+	if len(res.Content) != 2 {
+		t.Errorf("len(res.Content) = %d, want 2", len(res.Content))
+	}
+	want := &listClustersOutput{
+		Clusters: []*clusterOutput{{
+			Name:                 "c1",
+			Location:             "us-central1",
+			Status:               "RUNNING",
+			Conditions:           []string{"upgrading"},
+			Autopilot:            true,
+			ReleaseChannel:       "REGULAR",
+			CurrentMasterVersion: "1.33.1-gke.100",
+			NodePools:            []*nodePoolOutput{{Name: "default-pool", Status: "RUNNING"}},
+		}},
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("listClusters() output = %+v, want %+v", out, want)
+	}
+}
+
+func TestGetKubeconfig(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	t.Setenv("KUBECONFIG", kubeconfig)
+	ca := []byte("-----BEGIN CERTIFICATE-----\n")
+	f := &fakeClusterManager{clusters: &containerpb.ListClustersResponse{
+		Clusters: []*containerpb.Cluster{{
+			Name:       "c",
+			SelfLink:   "projects/p/locations/us-central1/clusters/c",
+			Endpoint:   "10.0.0.2",
+			MasterAuth: &containerpb.MasterAuth{ClusterCaCertificate: base64.StdEncoding.EncodeToString(ca)},
+			ControlPlaneEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig{
+				DnsEndpointConfig: &containerpb.ControlPlaneEndpointsConfig_DNSEndpointConfig{Endpoint: "gke-abc.us-central1.gke.goog", AllowExternalTraffic: proto.Bool(true)},
+				IpEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig_IPEndpointsConfig{
+					Enabled:              proto.Bool(true),
+					EnablePublicEndpoint: proto.Bool(false),
+					PrivateEndpoint:      "10.0.0.2",
+				},
+			},
+		}},
+	}}
+	h := &handlers{c: config.NewTestConfig("p", "us-central1", "", "", config.WithClusterEndpoint("dns")), cmClient: newFakeClusterManagerClient(t, f)}
+	ctx := context.Background()
+	args := &getKubeconfigArgs{}
+	args.ProjectID = "p"
+	args.Location = "us-central1"
+	args.ClusterName = "c"
+
+	// The server's endpoint type is the default.
+	if _, _, err := h.getKubeconfig(ctx, &mcp.CallToolRequest{}, args); err != nil {
+		t.Fatalf("getKubeconfig() failed: %v", err)
+	}
+	got, err := clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+	cluster := got.Clusters["gke_p_us-central1_c"]
+	if cluster == nil || cluster.Server != "https://gke-abc.us-central1.gke.goog" || cluster.CertificateAuthorityData != nil {
+		t.Errorf("cluster = %+v, want the DNS-based endpoint without a CA", cluster)
+	}
+
+	args.Endpoint = "private"
+	if _, _, err := h.getKubeconfig(ctx, &mcp.CallToolRequest{}, args); err != nil {
+		t.Fatalf("getKubeconfig(private) failed: %v", err)
+	}
+	got, err = clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+	cluster = got.Clusters["gke_p_us-central1_c"]
+	if cluster == nil || cluster.Server != "https://10.0.0.2" || string(cluster.CertificateAuthorityData) != string(ca) {
+		t.Errorf("cluster = %+v, want the private endpoint with the CA", cluster)
+	}
+
+	args.Endpoint = "public"
+	_, _, err = h.getKubeconfig(ctx, &mcp.CallToolRequest{}, args)
+	if err == nil || !strings.Contains(err.Error(), "enabled endpoints: private (https://10.0.0.2), dns (https://gke-abc.us-central1.gke.goog)") {
+		t.Errorf("getKubeconfig(public) = %v, want an error listing the enabled endpoints", err)
+	}
+}
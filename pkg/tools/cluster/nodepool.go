@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/confirm"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/params"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -27,6 +28,7 @@ import (
 type createNodePoolArgs struct {
 	params.Cluster
 	NodePool string `json:"nodePool" jsonschema:"Required. The node pool to create represented as a string using JSON format."`
+	waitOption
 }
 
 type listNodePoolsArgs struct {
@@ -40,13 +42,65 @@ type getNodePoolArgs struct {
 type updateNodePoolArgs struct {
 	params.NodePool
 	Update string `json:"update" jsonschema:"Required. A node pool update request represented as a string using JSON format."`
+	waitOption
 }
 
 type deleteNodePoolArgs struct {
 	params.NodePool
+	waitOption
 }
 
-func (h *handlers) createNodePool(ctx context.Context, _ *mcp.CallToolRequest, args *createNodePoolArgs) (*mcp.CallToolResult, any, error) {
+// listNodePoolsOutput is the structured output of list_node_pools.
+type listNodePoolsOutput struct {
+	NodePools []*nodePoolOutput `json:"nodePools" jsonschema:"The node pools of the cluster."`
+}
+
+// nodePoolOutput describes a node pool in structured output.
+type nodePoolOutput struct {
+	Name             string               `json:"name" jsonschema:"The node pool name."`
+	Status           string               `json:"status,omitempty" jsonschema:"The node pool status, e.g. RUNNING, PROVISIONING, RECONCILING, ERROR."`
+	Version          string               `json:"version,omitempty" jsonschema:"The GKE version of the nodes."`
+	Locations        []string             `json:"locations,omitempty" jsonschema:"The zones the nodes are in."`
+	MachineType      string               `json:"machineType,omitempty"`
+	DiskSizeGB       int32                `json:"diskSizeGb,omitempty"`
+	Spot             bool                 `json:"spot,omitempty" jsonschema:"Whether the nodes are Spot VMs."`
+	InitialNodeCount int32                `json:"initialNodeCount,omitempty" jsonschema:"The number of nodes per zone the node pool was created with."`
+	Autoscaling      *nodePoolAutoscaling `json:"autoscaling,omitempty" jsonschema:"The autoscaling limits, if autoscaling is enabled."`
+}
+
+// nodePoolAutoscaling holds the node limits of an autoscaled node pool.
+type nodePoolAutoscaling struct {
+	MinNodeCount      int32 `json:"minNodeCount,omitempty" jsonschema:"The minimum number of nodes per zone."`
+	MaxNodeCount      int32 `json:"maxNodeCount,omitempty" jsonschema:"The maximum number of nodes per zone."`
+	TotalMinNodeCount int32 `json:"totalMinNodeCount,omitempty" jsonschema:"The minimum number of nodes in the node pool."`
+	TotalMaxNodeCount int32 `json:"totalMaxNodeCount,omitempty" jsonschema:"The maximum number of nodes in the node pool."`
+}
+
+func newNodePoolOutput(np *containerpb.NodePool) *nodePoolOutput {
+	out := &nodePoolOutput{
+		Name:             np.GetName(),
+		Version:          np.GetVersion(),
+		Locations:        np.GetLocations(),
+		MachineType:      np.GetConfig().GetMachineType(),
+		DiskSizeGB:       np.GetConfig().GetDiskSizeGb(),
+		Spot:             np.GetConfig().GetSpot(),
+		InitialNodeCount: np.GetInitialNodeCount(),
+	}
+	if np.GetStatus() != containerpb.NodePool_STATUS_UNSPECIFIED {
+		out.Status = np.GetStatus().String()
+	}
+	if a := np.GetAutoscaling(); a.GetEnabled() {
+		out.Autoscaling = &nodePoolAutoscaling{
+			MinNodeCount:      a.GetMinNodeCount(),
+			MaxNodeCount:      a.GetMaxNodeCount(),
+			TotalMinNodeCount: a.GetTotalMinNodeCount(),
+			TotalMaxNodeCount: a.GetTotalMaxNodeCount(),
+		}
+	}
+	return out
+}
+
+func (h *handlers) createNodePool(ctx context.Context, toolReq *mcp.CallToolRequest, args *createNodePoolArgs) (*mcp.CallToolResult, any, error) {
 	var nodePoolObj containerpb.NodePool
 	if err := protojson.Unmarshal([]byte(args.NodePool), &nodePoolObj); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal node pool JSON: %w", err)
@@ -61,14 +115,10 @@ func (h *handlers) createNodePool(ctx context.Context, _ *mcp.CallToolRequest, a
 		return nil, nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: protojson.Format(resp)},
-		},
-	}, nil, nil
+	return h.operationResult(ctx, toolReq, args.LocationPath(), resp, args.Wait)
 }
 
-func (h *handlers) listNodePools(ctx context.Context, _ *mcp.CallToolRequest, args *listNodePoolsArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) listNodePools(ctx context.Context, _ *mcp.CallToolRequest, args *listNodePoolsArgs) (*mcp.CallToolResult, *listNodePoolsOutput, error) {
 	req := &containerpb.ListNodePoolsRequest{
 		Parent: args.ClusterPath(),
 	}
@@ -77,11 +127,16 @@ func (h *handlers) listNodePools(ctx context.Context, _ *mcp.CallToolRequest, ar
 		return nil, nil, err
 	}
 
+	out := &listNodePoolsOutput{NodePools: make([]*nodePoolOutput, 0, len(resp.GetNodePools()))}
+	for _, np := range resp.GetNodePools() {
+		out.NodePools = append(out.NodePools, newNodePoolOutput(np))
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: protojson.Format(resp)},
 		},
-	}, nil, nil
+	}, out, nil
 }
 
 func (h *handlers) getNodePool(ctx context.Context, _ *mcp.CallToolRequest, args *getNodePoolArgs) (*mcp.CallToolResult, any, error) {
@@ -100,26 +155,30 @@ func (h *handlers) getNodePool(ctx context.Context, _ *mcp.CallToolRequest, args
 	}, nil, nil
 }
 
-func (h *handlers) updateNodePool(ctx context.Context, _ *mcp.CallToolRequest, args *updateNodePoolArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) updateNodePool(ctx context.Context, toolReq *mcp.CallToolRequest, args *updateNodePoolArgs) (*mcp.CallToolResult, any, error) {
 	var req containerpb.UpdateNodePoolRequest
 	if err := protojson.Unmarshal([]byte(args.Update), &req); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal update JSON: %w", err)
 	}
 	req.Name = args.NodePoolPath()
+	msg := fmt.Sprintf("Update node pool %s with the following changes? Nodes may be recreated, evicting the pods running on them.\n\n%s", args.NodePoolPath(), protojson.Format(&req))
+	if res, err := confirm.Request(toolReq, h.c, msg); res != nil || err != nil {
+		return res, nil, err
+	}
 
 	resp, err := h.cmClient.UpdateNodePool(ctx, &req)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: protojson.Format(resp)},
-		},
-	}, nil, nil
+	return h.operationResult(ctx, toolReq, args.LocationPath(), resp, args.Wait)
 }
 
-func (h *handlers) deleteNodePool(ctx context.Context, _ *mcp.CallToolRequest, args *deleteNodePoolArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) deleteNodePool(ctx context.Context, toolReq *mcp.CallToolRequest, args *deleteNodePoolArgs) (*mcp.CallToolResult, any, error) {
+	msg := fmt.Sprintf("Delete node pool %s? All of its nodes will be deleted and the pods running on them evicted.", args.NodePoolPath())
+	if res, err := confirm.Request(toolReq, h.c, msg); res != nil || err != nil {
+		return res, nil, err
+	}
 	req := &containerpb.DeleteNodePoolRequest{
 		Name: args.NodePoolPath(),
 	}
@@ -128,9 +187,5 @@ func (h *handlers) deleteNodePool(ctx context.Context, _ *mcp.CallToolRequest, a
 		return nil, nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: protojson.Format(resp)},
-		},
-	}, nil, nil
+	return h.operationResult(ctx, toolReq, args.LocationPath(), resp, args.Wait)
 }
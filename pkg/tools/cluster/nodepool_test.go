@@ -15,7 +15,10 @@
 package cluster
 
 import (
+	"reflect"
 	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
 )
 
 func TestCreateNodePoolArgs_Fields(t *testing.T) {
@@ -122,3 +125,33 @@ func TestDeleteNodePoolArgs_Fields(t *testing.T) {
 		t.Errorf("NodePoolName = %s, want my-pool", args.NodePoolName)
 	}
 }
+
+func TestNewNodePoolOutput(t *testing.T) {
+	got := newNodePoolOutput(&containerpb.NodePool{
+		Name:             "pool",
+		Status:           containerpb.NodePool_RECONCILING,
+		Version:          "1.33.1-gke.100",
+		Locations:        []string{"us-central1-a"},
+		InitialNodeCount: 3,
+		Config:           &containerpb.NodeConfig{MachineType: "e2-standard-4", DiskSizeGb: 100, Spot: true},
+		Autoscaling:      &containerpb.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 5},
+	})
+	want := &nodePoolOutput{
+		Name:             "pool",
+		Status:           "RECONCILING",
+		Version:          "1.33.1-gke.100",
+		Locations:        []string{"us-central1-a"},
+		MachineType:      "e2-standard-4",
+		DiskSizeGB:       100,
+		Spot:             true,
+		InitialNodeCount: 3,
+		Autoscaling:      &nodePoolAutoscaling{MinNodeCount: 1, MaxNodeCount: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newNodePoolOutput() = %+v, want %+v", got, want)
+	}
+
+	if got := newNodePoolOutput(&containerpb.NodePool{Name: "pool", Autoscaling: &containerpb.NodePoolAutoscaling{MaxNodeCount: 5}}); got.Autoscaling != nil {
+		t.Errorf("newNodePoolOutput() with autoscaling disabled has autoscaling %+v, want nil", got.Autoscaling)
+	}
+}
@@ -17,13 +17,30 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/confirm"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/params"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/genproto/googleapis/rpc/code"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// defaultWaitTimeout is how long to wait for an operation to finish if the
+// tool call does not say.
+const defaultWaitTimeout = 30 * time.Minute
+
+// pollInterval is how often a running operation is checked while waiting.
+var pollInterval = 5 * time.Second
+
+// waitOption is embedded in the arguments of tools that start an operation.
+type waitOption struct {
+	Wait bool `json:"wait,omitempty" jsonschema:"Optional. If true, wait for the operation to finish, reporting its progress, and return its final status and error details instead of the running operation. Default: false."`
+}
+
 type listOperationsArgs struct {
 	params.LocationRequired
 }
@@ -36,7 +53,61 @@ type cancelOperationArgs struct {
 	params.Operation
 }
 
-func (h *handlers) listOperations(ctx context.Context, _ *mcp.CallToolRequest, args *listOperationsArgs) (*mcp.CallToolResult, any, error) {
+type waitForOperationArgs struct {
+	params.Operation
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty" jsonschema:"Optional. How long to wait before returning an operation that is still running. Default: 1800 (30 minutes)."`
+}
+
+// listOperationsOutput is the structured output of list_operations.
+type listOperationsOutput struct {
+	Operations   []*operationOutput `json:"operations" jsonschema:"The operations found."`
+	MissingZones []string           `json:"missingZones,omitempty" jsonschema:"Locations that could not be reached, whose operations are missing from the list."`
+}
+
+// operationOutput describes an operation in structured output.
+type operationOutput struct {
+	Name          string          `json:"name" jsonschema:"The operation ID."`
+	OperationType string          `json:"operationType,omitempty" jsonschema:"The operation type, e.g. CREATE_CLUSTER, UPGRADE_NODES."`
+	Status        string          `json:"status,omitempty" jsonschema:"The operation status: PENDING, RUNNING, DONE or ABORTING."`
+	Detail        string          `json:"detail,omitempty" jsonschema:"Detailed progress of the operation, if available."`
+	Location      string          `json:"location,omitempty" jsonschema:"The region or zone of the operation."`
+	TargetLink    string          `json:"targetLink,omitempty" jsonschema:"The URI of the resource the operation modifies."`
+	StartTime     string          `json:"startTime,omitempty" jsonschema:"When the operation started, in RFC3339 format."`
+	EndTime       string          `json:"endTime,omitempty" jsonschema:"When the operation finished, in RFC3339 format."`
+	Error         *operationError `json:"error,omitempty" jsonschema:"The error the operation failed with, if any."`
+}
+
+// operationError is the error of a failed operation.
+type operationError struct {
+	Code    string `json:"code" jsonschema:"The canonical error code, e.g. RESOURCE_EXHAUSTED."`
+	Message string `json:"message"`
+}
+
+func newOperationOutput(op *containerpb.Operation) *operationOutput {
+	out := &operationOutput{
+		Name:       op.GetName(),
+		Detail:     op.GetDetail(),
+		Location:   op.GetLocation(),
+		TargetLink: op.GetTargetLink(),
+		StartTime:  op.GetStartTime(),
+		EndTime:    op.GetEndTime(),
+	}
+	if op.GetOperationType() != containerpb.Operation_TYPE_UNSPECIFIED {
+		out.OperationType = op.GetOperationType().String()
+	}
+	if op.GetStatus() != containerpb.Operation_STATUS_UNSPECIFIED {
+		out.Status = op.GetStatus().String()
+	}
+	if st := op.GetError(); st.GetCode() != 0 {
+		out.Error = &operationError{
+			Code:    code.Code(st.GetCode()).String(),
+			Message: st.GetMessage(),
+		}
+	}
+	return out
+}
+
+func (h *handlers) listOperations(ctx context.Context, _ *mcp.CallToolRequest, args *listOperationsArgs) (*mcp.CallToolResult, *listOperationsOutput, error) {
 	if h.cmClient == nil {
 		return nil, nil, fmt.Errorf("client not initialized")
 	}
@@ -48,11 +119,19 @@ func (h *handlers) listOperations(ctx context.Context, _ *mcp.CallToolRequest, a
 		return nil, nil, err
 	}
 
+	out := &listOperationsOutput{
+		Operations:   make([]*operationOutput, 0, len(resp.GetOperations())),
+		MissingZones: resp.GetMissingZones(),
+	}
+	for _, op := range resp.GetOperations() {
+		out.Operations = append(out.Operations, newOperationOutput(op))
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: protojson.Format(resp)},
 		},
-	}, nil, nil
+	}, out, nil
 }
 
 func (h *handlers) getOperation(ctx context.Context, _ *mcp.CallToolRequest, args *getOperationArgs) (*mcp.CallToolResult, any, error) {
@@ -74,10 +153,14 @@ func (h *handlers) getOperation(ctx context.Context, _ *mcp.CallToolRequest, arg
 	}, nil, nil
 }
 
-func (h *handlers) cancelOperation(ctx context.Context, _ *mcp.CallToolRequest, args *cancelOperationArgs) (*mcp.CallToolResult, any, error) {
+func (h *handlers) cancelOperation(ctx context.Context, toolReq *mcp.CallToolRequest, args *cancelOperationArgs) (*mcp.CallToolResult, any, error) {
 	if h.cmClient == nil {
 		return nil, nil, fmt.Errorf("client not initialized")
 	}
+	msg := fmt.Sprintf("Cancel GKE operation %s? The operation stops where it is, which may leave the cluster or node pool partially updated.", args.OperationPath())
+	if res, err := confirm.Request(toolReq, h.c, msg); res != nil || err != nil {
+		return res, nil, err
+	}
 	req := &containerpb.CancelOperationRequest{
 		Name: args.OperationPath(),
 	}
@@ -92,3 +175,186 @@ func (h *handlers) cancelOperation(ctx context.Context, _ *mcp.CallToolRequest,
 		},
 	}, nil, nil
 }
+
+func (h *handlers) waitForOperation(ctx context.Context, req *mcp.CallToolRequest, args *waitForOperationArgs) (*mcp.CallToolResult, any, error) {
+	if h.cmClient == nil {
+		return nil, nil, fmt.Errorf("client not initialized")
+	}
+	timeout := defaultWaitTimeout
+	if args.TimeoutSeconds > 0 {
+		timeout = time.Duration(args.TimeoutSeconds) * time.Second
+	}
+	return h.awaitOperation(ctx, req, args.OperationPath(), timeout)
+}
+
+// operationResult returns the result of a tool that started op in the
+// location at locationPath: op itself, or its final state if wait is set.
+func (h *handlers) operationResult(ctx context.Context, req *mcp.CallToolRequest, locationPath string, op *containerpb.Operation, wait bool) (*mcp.CallToolResult, any, error) {
+	if !wait {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: protojson.Format(op)},
+			},
+		}, nil, nil
+	}
+	return h.awaitOperation(ctx, req, fmt.Sprintf("%s/operations/%s", locationPath, op.GetName()), defaultWaitTimeout)
+}
+
+// awaitOperation polls the named operation until it is done, timeout has
+// passed or ctx is cancelled, sending a progress notification whenever a poll
+// shows progress if the client asked for them.
+func (h *handlers) awaitOperation(ctx context.Context, req *mcp.CallToolRequest, name string, timeout time.Duration) (*mcp.CallToolResult, any, error) {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	progress := &progressNotifier{req: req}
+	for {
+		op, err := h.cmClient.GetOperation(ctx, &containerpb.GetOperationRequest{Name: name})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, stoppedWaiting(name, ctx.Err())
+			}
+			return nil, nil, err
+		}
+		progress.notify(ctx, op)
+
+		if op.GetStatus() == containerpb.Operation_DONE {
+			return doneResult(name, op), nil, nil
+		}
+		if !time.Now().Before(deadline) {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Operation %s is still %s after %s. Call wait_for_operation to keep waiting.", name, op.GetStatus(), timeout)},
+					&mcp.TextContent{Text: protojson.Format(op)},
+				},
+			}, nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, stoppedWaiting(name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func stoppedWaiting(name string, err error) error {
+	return fmt.Errorf("stopped waiting for operation %s: %w. The operation keeps running; use get_operation or wait_for_operation to check on it", name, err)
+}
+
+// doneResult reports the final status of a finished operation, as an error
+// result if the operation failed.
+func doneResult(name string, op *containerpb.Operation) *mcp.CallToolResult {
+	if st := op.GetError(); st.GetCode() != 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Operation %s failed with %s: %s", name, code.Code(st.GetCode()), st.GetMessage())},
+				&mcp.TextContent{Text: protojson.Format(op)},
+			},
+			IsError: true,
+		}
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Operation %s finished.", name)},
+			&mcp.TextContent{Text: protojson.Format(op)},
+		},
+	}
+}
+
+// progressNotifier sends the progress of an operation to the client, if it
+// sent a progress token with req. Progress is reported as a percentage and
+// only sent when it has increased, as MCP requires.
+type progressNotifier struct {
+	req  *mcp.CallToolRequest
+	sent bool
+	last float64
+}
+
+func (n *progressNotifier) notify(ctx context.Context, op *containerpb.Operation) {
+	if n.req == nil || n.req.Session == nil || n.req.Params == nil {
+		return
+	}
+	token := n.req.Params.GetProgressToken()
+	if token == nil {
+		return
+	}
+	progress, ok := operationProgress(op)
+	if !ok || progress < n.last {
+		progress = n.last
+	}
+	if n.sent && progress == n.last {
+		return
+	}
+	n.sent = true
+	n.last = progress
+	if err := n.req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: token,
+		Message:       progressMessage(op),
+		Progress:      progress,
+		Total:         100,
+	}); err != nil {
+		serverlog.Warningf(ctx, "Failed to send progress notification: %v", err)
+	}
+}
+
+// operationProgress returns the percentage of op that is done. GKE reports
+// how far most operations have got with metric pairs such as NODES_DONE and
+// NODES_TOTAL, or a percentage; ok is false if op has neither.
+func operationProgress(op *containerpb.Operation) (percent float64, ok bool) {
+	if op.GetStatus() == containerpb.Operation_DONE {
+		return 100, true
+	}
+	var names []string
+	values := make(map[string]float64)
+	for _, m := range op.GetProgress().GetMetrics() {
+		name := strings.ToUpper(strings.ReplaceAll(m.GetName(), " ", "_"))
+		switch v := m.GetValue().(type) {
+		case *containerpb.OperationProgress_Metric_IntValue:
+			values[name] = float64(v.IntValue)
+		case *containerpb.OperationProgress_Metric_DoubleValue:
+			values[name] = v.DoubleValue
+		default:
+			continue
+		}
+		names = append(names, name)
+	}
+	if percent, ok := values["PERCENT_DONE"]; ok {
+		return min(max(percent, 0), 100), true
+	}
+	for _, name := range names {
+		prefix, ok := strings.CutSuffix(name, "_TOTAL")
+		if !ok || values[name] <= 0 {
+			continue
+		}
+		if done, ok := values[prefix+"_DONE"]; ok {
+			return min(max(100*done/values[name], 0), 100), true
+		}
+	}
+	return 0, false
+}
+
+// progressMessage describes the state of op, such as
+// "UPGRADE_NODES RUNNING: NODES_DONE=2, NODES_TOTAL=3".
+func progressMessage(op *containerpb.Operation) string {
+	msg := fmt.Sprintf("%s %s", op.GetOperationType(), op.GetStatus())
+	var metrics []string
+	for _, m := range op.GetProgress().GetMetrics() {
+		switch v := m.GetValue().(type) {
+		case *containerpb.OperationProgress_Metric_IntValue:
+			metrics = append(metrics, fmt.Sprintf("%s=%d", m.GetName(), v.IntValue))
+		case *containerpb.OperationProgress_Metric_DoubleValue:
+			metrics = append(metrics, fmt.Sprintf("%s=%g", m.GetName(), v.DoubleValue))
+		case *containerpb.OperationProgress_Metric_StringValue:
+			metrics = append(metrics, fmt.Sprintf("%s=%s", m.GetName(), v.StringValue))
+		}
+	}
+	if len(metrics) > 0 {
+		msg += ": " + strings.Join(metrics, ", ")
+	}
+	if op.GetDetail() != "" {
+		msg += " (" + op.GetDetail() + ")"
+	}
+	return msg
+}
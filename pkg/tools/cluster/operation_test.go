@@ -16,7 +16,22 @@ package cluster
 
 import (
 	"context"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/option"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 func TestListOperationsArgs_Fields(t *testing.T) {
@@ -98,3 +113,342 @@ func TestCancelOperation_Handler(t *testing.T) {
 		t.Errorf("Expected 'client not initialized', got %v", err)
 	}
 }
+
+func TestWaitForOperation_Handler(t *testing.T) {
+	h := &handlers{}
+	_, _, err := h.waitForOperation(context.Background(), nil, &waitForOperationArgs{})
+	if err == nil || err.Error() != "client not initialized" {
+		t.Errorf("Expected 'client not initialized', got %v", err)
+	}
+}
+
+// fakeClusterManager returns the operations in ops from successive
+// GetOperation calls, repeating the last one, clusters and operations from
+// ListClusters and ListOperations, and the clusters and node pools in them by
+// name.
+type fakeClusterManager struct {
+	containerpb.UnimplementedClusterManagerServer
+
+	mu         sync.Mutex
+	ops        []*containerpb.Operation
+	names      []string
+	clusters   *containerpb.ListClustersResponse
+	operations *containerpb.ListOperationsResponse
+}
+
+func (f *fakeClusterManager) ListClusters(context.Context, *containerpb.ListClustersRequest) (*containerpb.ListClustersResponse, error) {
+	return f.clusters, nil
+}
+
+func (f *fakeClusterManager) GetCluster(_ context.Context, req *containerpb.GetClusterRequest) (*containerpb.Cluster, error) {
+	for _, c := range f.clusters.GetClusters() {
+		if c.GetSelfLink() == req.GetName() {
+			return c, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "cluster %s not found", req.GetName())
+}
+
+func (f *fakeClusterManager) GetNodePool(_ context.Context, req *containerpb.GetNodePoolRequest) (*containerpb.NodePool, error) {
+	for _, c := range f.clusters.GetClusters() {
+		for _, np := range c.GetNodePools() {
+			if np.GetSelfLink() == req.GetName() {
+				return np, nil
+			}
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "node pool %s not found", req.GetName())
+}
+
+func (f *fakeClusterManager) ListOperations(context.Context, *containerpb.ListOperationsRequest) (*containerpb.ListOperationsResponse, error) {
+	return f.operations, nil
+}
+
+func (f *fakeClusterManager) GetOperation(_ context.Context, req *containerpb.GetOperationRequest) (*containerpb.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.names = append(f.names, req.GetName())
+	op := f.ops[0]
+	if len(f.ops) > 1 {
+		f.ops = f.ops[1:]
+	}
+	return op, nil
+}
+
+// newFakeClusterManagerClient serves f on a local port and returns a client
+// connected to it.
+func newFakeClusterManagerClient(t *testing.T, f *fakeClusterManager) *container.ClusterManagerClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	containerpb.RegisterClusterManagerServer(srv, f)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	client, err := container.NewClusterManagerClient(context.Background(),
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func intMetric(name string, v int64) *containerpb.OperationProgress_Metric {
+	return &containerpb.OperationProgress_Metric{Name: name, Value: &containerpb.OperationProgress_Metric_IntValue{IntValue: v}}
+}
+
+func runningUpgrade(done, total int64) *containerpb.Operation {
+	return &containerpb.Operation{
+		Name:          "op-1",
+		OperationType: containerpb.Operation_UPGRADE_NODES,
+		Status:        containerpb.Operation_RUNNING,
+		Progress: &containerpb.OperationProgress{
+			Metrics: []*containerpb.OperationProgress_Metric{intMetric("NODES_TOTAL", total), intMetric("NODES_DONE", done)},
+		},
+	}
+}
+
+// callWaitForOperation calls wait_for_operation through an in-memory session
+// with a progress token and returns the result and the progress notifications
+// the client received.
+func callWaitForOperation(t *testing.T, h *handlers, args map[string]any) (*mcp.CallToolResult, []*mcp.ProgressNotificationParams) {
+	t.Helper()
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "wait_for_operation"}, h.waitForOperation)
+
+	var mu sync.Mutex
+	var notifications []*mcp.ProgressNotificationParams
+	client := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			notifications = append(notifications, req.Params)
+		},
+	})
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	defer func() { _ = serverSession.Close() }()
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() failed: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	params := &mcp.CallToolParams{Name: "wait_for_operation", Arguments: args}
+	params.SetProgressToken("token")
+	result, err := session.CallTool(ctx, params)
+	if err != nil {
+		t.Fatalf("CallTool() failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return result, notifications
+}
+
+func setPollInterval(t *testing.T, d time.Duration) {
+	t.Helper()
+	orig := pollInterval
+	pollInterval = d
+	t.Cleanup(func() { pollInterval = orig })
+}
+
+func TestWaitForOperation(t *testing.T) {
+	setPollInterval(t, time.Millisecond)
+	fake := &fakeClusterManager{ops: []*containerpb.Operation{
+		runningUpgrade(0, 3),
+		runningUpgrade(2, 3),
+		{Name: "op-1", OperationType: containerpb.Operation_UPGRADE_NODES, Status: containerpb.Operation_DONE},
+	}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, fake)}
+
+	result, notifications := callWaitForOperation(t, h, map[string]any{"project_id": "p", "location": "us-central1", "operation_id": "op-1"})
+	if result.IsError {
+		t.Fatalf("wait_for_operation returned error result: %v", result.Content[0])
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; text != "Operation projects/p/locations/us-central1/operations/op-1 finished." {
+		t.Errorf("result = %q", text)
+	}
+	if len(fake.names) != 3 || fake.names[0] != "projects/p/locations/us-central1/operations/op-1" {
+		t.Errorf("GetOperation calls = %v, want 3 for the operation", fake.names)
+	}
+
+	// Notifications may arrive after the result, so only check those received.
+	want := []float64{0, 200.0 / 3, 100}
+	if len(notifications) > len(want) {
+		t.Errorf("got %d notifications, want at most %d", len(notifications), len(want))
+	}
+	for i, n := range notifications[:min(len(notifications), len(want))] {
+		if n.Progress != want[i] || n.Total != 100 {
+			t.Errorf("notification %d = %v/%v, want %v/100", i, n.Progress, n.Total, want[i])
+		}
+	}
+	if len(notifications) > 0 && notifications[0].Message != "UPGRADE_NODES RUNNING: NODES_TOTAL=3, NODES_DONE=0" {
+		t.Errorf("first notification message = %q", notifications[0].Message)
+	}
+}
+
+func TestWaitForOperationFailed(t *testing.T) {
+	setPollInterval(t, time.Millisecond)
+	fake := &fakeClusterManager{ops: []*containerpb.Operation{{
+		Name:   "op-1",
+		Status: containerpb.Operation_DONE,
+		Error:  &statuspb.Status{Code: int32(codes.ResourceExhausted), Message: "quota exceeded"},
+	}}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, fake)}
+
+	result, _ := callWaitForOperation(t, h, map[string]any{"project_id": "p", "location": "us-central1", "operation_id": "op-1"})
+	if !result.IsError {
+		t.Fatal("wait_for_operation succeeded for a failed operation")
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "failed with RESOURCE_EXHAUSTED: quota exceeded") {
+		t.Errorf("result = %q, want the error details", text)
+	}
+}
+
+func TestWaitForOperationTimeout(t *testing.T) {
+	setPollInterval(t, 10*time.Millisecond)
+	fake := &fakeClusterManager{ops: []*containerpb.Operation{runningUpgrade(1, 3)}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, fake)}
+
+	result, _ := callWaitForOperation(t, h, map[string]any{"project_id": "p", "location": "us-central1", "operation_id": "op-1", "timeoutSeconds": 1})
+	if result.IsError {
+		t.Fatalf("wait_for_operation returned error result: %v", result.Content[0])
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "is still RUNNING after 1s") {
+		t.Errorf("result = %q, want still running", text)
+	}
+}
+
+func TestWaitForOperationCancelled(t *testing.T) {
+	setPollInterval(t, time.Hour)
+	fake := &fakeClusterManager{ops: []*containerpb.Operation{runningUpgrade(1, 3)}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, fake)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, err := h.awaitOperation(ctx, nil, "projects/p/locations/l/operations/op-1", time.Hour)
+	if err == nil || !strings.Contains(err.Error(), "stopped waiting") || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("awaitOperation() = %v, want stopped waiting error", err)
+	}
+}
+
+func TestOperationResult(t *testing.T) {
+	setPollInterval(t, time.Millisecond)
+	fake := &fakeClusterManager{ops: []*containerpb.Operation{{Name: "op-2", Status: containerpb.Operation_DONE}}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, fake)}
+	op := &containerpb.Operation{Name: "op-2", Status: containerpb.Operation_RUNNING}
+
+	result, _, err := h.operationResult(context.Background(), nil, "projects/p/locations/l", op, false)
+	if err != nil || len(result.Content) != 1 || len(fake.names) != 0 {
+		t.Errorf("operationResult(wait=false) = %v, %v with %d polls, want the operation without polling", result, err, len(fake.names))
+	}
+
+	result, _, err = h.operationResult(context.Background(), nil, "projects/p/locations/l", op, true)
+	if err != nil {
+		t.Fatalf("operationResult(wait=true) failed: %v", err)
+	}
+	if len(fake.names) != 1 || fake.names[0] != "projects/p/locations/l/operations/op-2" {
+		t.Errorf("GetOperation calls = %v, want one for the operation", fake.names)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.HasSuffix(text, "finished.") {
+		t.Errorf("result = %q, want finished", text)
+	}
+}
+
+func TestOperationProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		op          *containerpb.Operation
+		wantPercent float64
+		wantOK      bool
+	}{
+		{name: "node counts", op: runningUpgrade(2, 5), wantPercent: 40, wantOK: true},
+		{name: "percent", op: &containerpb.Operation{Progress: &containerpb.OperationProgress{Metrics: []*containerpb.OperationProgress_Metric{
+			{Name: "percent done", Value: &containerpb.OperationProgress_Metric_DoubleValue{DoubleValue: 42.5}},
+		}}}, wantPercent: 42.5, wantOK: true},
+		{name: "no metrics", op: &containerpb.Operation{Status: containerpb.Operation_RUNNING}},
+		{name: "done", op: &containerpb.Operation{Status: containerpb.Operation_DONE}, wantPercent: 100, wantOK: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			percent, ok := operationProgress(tc.op)
+			if percent != tc.wantPercent || ok != tc.wantOK {
+				t.Errorf("operationProgress() = %v, %v, want %v, %v", percent, ok, tc.wantPercent, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestWaitForOperationProgressNeverDecreases(t *testing.T) {
+	setPollInterval(t, time.Millisecond)
+	percentDone := func(v float64) *containerpb.Operation {
+		return &containerpb.Operation{Name: "op-1", Status: containerpb.Operation_RUNNING, Progress: &containerpb.OperationProgress{
+			Metrics: []*containerpb.OperationProgress_Metric{{Name: "PERCENT_DONE", Value: &containerpb.OperationProgress_Metric_DoubleValue{DoubleValue: v}}},
+		}}
+	}
+	fake := &fakeClusterManager{ops: []*containerpb.Operation{
+		{Name: "op-1", Status: containerpb.Operation_PENDING},
+		percentDone(50),
+		runningUpgrade(1, 4),
+		{Name: "op-1", Status: containerpb.Operation_RUNNING},
+		runningUpgrade(3, 4),
+		{Name: "op-1", Status: containerpb.Operation_DONE},
+	}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, fake)}
+
+	_, notifications := callWaitForOperation(t, h, map[string]any{"project_id": "p", "location": "us-central1", "operation_id": "op-1"})
+	want := []float64{0, 50, 75, 100}
+	if len(notifications) > len(want) {
+		t.Errorf("got %d notifications, want at most %d", len(notifications), len(want))
+	}
+	for i, n := range notifications[:min(len(notifications), len(want))] {
+		if n.Progress != want[i] || n.Total != 100 {
+			t.Errorf("notification %d = %v/%v, want %v/100", i, n.Progress, n.Total, want[i])
+		}
+	}
+}
+
+func TestListOperations(t *testing.T) {
+	f := &fakeClusterManager{operations: &containerpb.ListOperationsResponse{
+		Operations: []*containerpb.Operation{{
+			Name:          "operation-1",
+			OperationType: containerpb.Operation_UPGRADE_NODES,
+			Status:        containerpb.Operation_DONE,
+			Location:      "us-central1",
+			Error:         &statuspb.Status{Code: int32(codes.ResourceExhausted), Message: "out of quota"},
+		}},
+		MissingZones: []string{"us-east1-b"},
+	}}
+	h := &handlers{cmClient: newFakeClusterManagerClient(t, f)}
+
+	res, out, err := h.listOperations(context.Background(), &mcp.CallToolRequest{}, &listOperationsArgs{})
+	if err != nil {
+		t.Fatalf("listOperations() failed: %v", err)
+	}
+	if len(res.Content) != 1 {
+		t.Errorf("len(res.Content) = %d, want 1", len(res.Content))
+	}
+	want := &listOperationsOutput{
+		Operations: []*operationOutput{{
+			Name:          "operation-1",
+			OperationType: "UPGRADE_NODES",
+			Status:        "DONE",
+			Location:      "us-central1",
+			Error:         &operationError{Code: "RESOURCE_EXHAUSTED", Message: "out of quota"},
+		}},
+		MissingZones: []string{"us-east1-b"},
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("listOperations() output = %+v, want %+v", out, want)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
+	"github.com/googleapis/gax-go/v2/callctx"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// resourceScheme prefixes the GKE API resource name of a cluster or node pool
+// to form its MCP resource URI, e.g.
+// gke://projects/p/locations/us-central1/clusters/c.
+const resourceScheme = "gke://"
+
+const (
+	clustersURITemplate = resourceScheme + "projects/{project}/locations/{location}/clusters"
+	clusterURITemplate  = clustersURITemplate + "/{cluster}"
+	nodePoolURITemplate = clusterURITemplate + "/nodePools/{pool}"
+
+	resourceMIMEType = "application/json"
+)
+
+// installResources registers the clusters and node pools of any project as
+// resource templates, and the clusters of the default project as a resource.
+// Each is only registered if the tool that reads the same data is enabled.
+func installResources(s *mcp.Server, h *handlers) {
+	registry.AddResourceTemplate(s, h.c, "list_clusters", &mcp.ResourceTemplate{
+		Name:        "clusters",
+		Title:       "GKE clusters",
+		Description: "The GKE clusters in a project and location. Use - as the location for all locations.",
+		URITemplate: clustersURITemplate,
+		MIMEType:    resourceMIMEType,
+	}, h.readClustersResource)
+
+	registry.AddResourceTemplate(s, h.c, "get_cluster", &mcp.ResourceTemplate{
+		Name:        "cluster",
+		Title:       "GKE cluster",
+		Description: "A GKE cluster, with the same fields as get_cluster.",
+		URITemplate: clusterURITemplate,
+		MIMEType:    resourceMIMEType,
+	}, h.readClusterResource)
+
+	registry.AddResourceTemplate(s, h.c, "get_node_pool", &mcp.ResourceTemplate{
+		Name:        "node_pool",
+		Title:       "GKE node pool",
+		Description: "A node pool of a GKE cluster.",
+		URITemplate: nodePoolURITemplate,
+		MIMEType:    resourceMIMEType,
+	}, h.readNodePoolResource)
+
+	if project := h.c.DefaultProjectID(); project != "" {
+		registry.AddResource(s, h.c, "list_clusters", &mcp.Resource{
+			Name:        "default_project_clusters",
+			Title:       "GKE clusters in " + project,
+			Description: fmt.Sprintf("The GKE clusters in all locations of the default project %s.", project),
+			URI:         fmt.Sprintf("%sprojects/%s/locations/-/clusters", resourceScheme, project),
+			MIMEType:    resourceMIMEType,
+		}, h.readClustersResource)
+	}
+}
+
+// resourceName returns the GKE API resource name in uri if it matches pattern,
+// a resource name whose IDs are replaced with *.
+func resourceName(uri, pattern string) (string, bool) {
+	name, ok := strings.CutPrefix(uri, resourceScheme)
+	if !ok {
+		return "", false
+	}
+	segments := strings.Split(name, "/")
+	want := strings.Split(pattern, "/")
+	if len(segments) != len(want) {
+		return "", false
+	}
+	for i, w := range want {
+		if w == "*" && segments[i] == "" || w != "*" && segments[i] != w {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+func (h *handlers) readClustersResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	parent, ok := resourceName(uri, "projects/*/locations/*/clusters")
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	ctx = callctx.SetHeaders(ctx, callctx.XGoogFieldMaskHeader, listClustersDefaultFieldMask)
+	resp, err := h.cmClient.ListClusters(ctx, &containerpb.ListClustersRequest{
+		Parent: strings.TrimSuffix(parent, "/clusters"),
+	})
+	return resourceResult(uri, resp, err)
+}
+
+func (h *handlers) readClusterResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	name, ok := resourceName(uri, "projects/*/locations/*/clusters/*")
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	ctx = callctx.SetHeaders(ctx, callctx.XGoogFieldMaskHeader, getClusterDefaultFieldMask)
+	resp, err := h.cmClient.GetCluster(ctx, &containerpb.GetClusterRequest{Name: name})
+	return resourceResult(uri, resp, err)
+}
+
+func (h *handlers) readNodePoolResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	name, ok := resourceName(uri, "projects/*/locations/*/clusters/*/nodePools/*")
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	resp, err := h.cmClient.GetNodePool(ctx, &containerpb.GetNodePoolRequest{Name: name})
+	return resourceResult(uri, resp, err)
+}
+
+// resourceResult returns m as the JSON contents of the resource at uri, or
+// err, reported as a missing resource if the API could not find it.
+func resourceResult(uri string, m proto.Message, err error) (*mcp.ReadResourceResult, error) {
+	if status.Code(err) == codes.NotFound {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: resourceMIMEType,
+				Text:     protojson.Format(m),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestResourceName(t *testing.T) {
+	const pattern = "projects/*/locations/*/clusters/*"
+	tests := []struct {
+		uri    string
+		want   string
+		wantOK bool
+	}{
+		{uri: "gke://projects/p/locations/l/clusters/c", want: "projects/p/locations/l/clusters/c", wantOK: true},
+		{uri: "gke://projects/p/locations/l/clusters/"},
+		{uri: "gke://projects/p/locations/l/clusters/c/nodePools/np"},
+		{uri: "gke://projects/p/zones/l/clusters/c"},
+		{uri: "k8s://projects/p/locations/l/clusters/c"},
+	}
+	for _, tc := range tests {
+		got, ok := resourceName(tc.uri, pattern)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("resourceName(%q) = %q, %t, want %q, %t", tc.uri, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+// connectResources installs the cluster resources backed by f on a server
+// configured with opts and returns a client session connected to it.
+func connectResources(t *testing.T, f *fakeClusterManager, opts ...config.Option) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	h := &handlers{
+		c:        config.NewTestConfig("p", "us-central1", "vertex-ai", "gemini-2.5-pro", opts...),
+		cmClient: newFakeClusterManagerClient(t, f),
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	installResources(server, h)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestReadResources(t *testing.T) {
+	const clusterName = "projects/p/locations/us-central1/clusters/c1"
+	f := &fakeClusterManager{clusters: &containerpb.ListClustersResponse{
+		Clusters: []*containerpb.Cluster{{
+			Name:     "c1",
+			SelfLink: clusterName,
+			NodePools: []*containerpb.NodePool{{
+				Name:     "default-pool",
+				SelfLink: clusterName + "/nodePools/default-pool",
+			}},
+		}},
+	}}
+	session := connectResources(t, f)
+	ctx := context.Background()
+
+	list, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources() failed: %v", err)
+	}
+	if len(list.Resources) != 1 || list.Resources[0].URI != "gke://projects/p/locations/-/clusters" {
+		t.Errorf("ListResources() = %v, want the clusters of the default project", list.Resources)
+	}
+
+	tests := []struct {
+		uri          string
+		wantContains string
+		wantNotFound bool
+	}{
+		{uri: "gke://projects/p/locations/-/clusters", wantContains: `"default-pool"`},
+		{uri: "gke://" + clusterName, wantContains: `"c1"`},
+		{uri: "gke://" + clusterName + "/nodePools/default-pool", wantContains: `"default-pool"`},
+		{uri: "gke://projects/p/locations/us-central1/clusters/missing", wantNotFound: true},
+		{uri: "gke://" + clusterName + "/nodePools/default-pool/extra", wantNotFound: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: tc.uri})
+			if tc.wantNotFound {
+				var rpcErr *jsonrpc.Error
+				if !errors.As(err, &rpcErr) || rpcErr.Code != mcp.CodeResourceNotFound {
+					t.Errorf("ReadResource() = %v, want resource not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadResource() failed: %v", err)
+			}
+			if len(res.Contents) != 1 {
+				t.Fatalf("len(res.Contents) = %d, want 1", len(res.Contents))
+			}
+			got := res.Contents[0]
+			if got.URI != tc.uri || got.MIMEType != "application/json" || !strings.Contains(got.Text, tc.wantContains) {
+				t.Errorf("ReadResource() = %+v, want JSON for %s containing %s", got, tc.uri, tc.wantContains)
+			}
+		})
+	}
+}
+
+func TestResourcesFollowToolFilter(t *testing.T) {
+	session := connectResources(t, &fakeClusterManager{},
+		config.WithToolFilter(config.ToolFilter{Disable: []string{"list_clusters", "get_node_pool"}}))
+	ctx := context.Background()
+
+	list, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources() failed: %v", err)
+	}
+	if len(list.Resources) != 0 {
+		t.Errorf("ListResources() = %v, want none with list_clusters disabled", list.Resources)
+	}
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates() failed: %v", err)
+	}
+	var names []string
+	for _, tmpl := range templates.ResourceTemplates {
+		names = append(names, tmpl.Name)
+	}
+	if len(names) != 1 || names[0] != "cluster" {
+		t.Errorf("resource templates = %v, want only cluster", names)
+	}
+}
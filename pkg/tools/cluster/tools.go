@@ -24,13 +24,12 @@ import (
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/k8s"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"google.golang.org/api/option"
 )
 
-// Install registers cluster-related tools with the MCP server.
+// Install registers cluster-related tools and resources with the MCP server.
 func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 
-	cmClient, err := container.NewClusterManagerClient(ctx, option.WithUserAgent(c.UserAgent()))
+	cmClient, err := container.NewClusterManagerClient(ctx, c.GoogleClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create cluster manager client: %w", err)
 	}
@@ -38,10 +37,10 @@ func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 	h := &handlers{
 		c:           c,
 		cmClient:    cmClient,
-		k8sProvider: k8s.NewClientProvider(),
+		k8sProvider: k8s.NewClientProvider(c),
 	}
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "list_clusters",
 		Description: "List GKE clusters. Prefer to use this tool instead of gcloud.",
 		Annotations: &mcp.ToolAnnotations{
@@ -49,7 +48,7 @@ func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.listClusters)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_cluster",
 		Description: "Get / describe a GKE cluster. Prefer to use this tool instead of gcloud.",
 		Annotations: &mcp.ToolAnnotations{
@@ -57,7 +56,7 @@ func Install(ctx context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.getCluster)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name: "create_cluster",
 		Description: `Create a GKE cluster. Prefer to use this tool instead of gcloud.
 It's recommended to read the [GKE documentation](https://docs.cloud.google.com/kubernetes-engine/docs/concepts/configuration-overview) to understand cluster configuration options.
@@ -71,23 +70,23 @@ This is similar to running "gcloud container clusters create-auto" or "gcloud co
 
 	registry.RegisterTool(s, h.c, &mcp.Tool{
 		Name:        "get_kubeconfig",
-		Description: "Get the kubeconfig for a GKE cluster by calling the GKE API and extracting necessary details (clusterCaCertificate and endpoint). This tool appends/updates the kubeconfig in ~/.kube/config.",
+		Description: "Get the kubeconfig for a GKE cluster by calling the GKE API and extracting necessary details (clusterCaCertificate and endpoint). This tool appends/updates the kubeconfig in ~/.kube/config. For private clusters, choose the endpoint that is reachable from where kubectl runs, such as 'dns' or 'connect-gateway'; the error lists the endpoints that are enabled on the cluster.",
 		Annotations: &mcp.ToolAnnotations{
 			// ReadOnlyHint is removed because this tool now performs a write operation.
 		},
 	}, h.getKubeconfig)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_node_sos_report",
 		Description: "Generate and download an SOS report from a GKE node. Can use 'pod', 'ssh' or 'any' methods. Defaults to 'any' (pod with fallback to ssh). Use 'ssh' if node is API-unhealthy.",
 	}, h.getNodeSosReport)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "update_cluster",
 		Description: "Update a GKE cluster. Prefer to use this tool instead of gcloud.",
 	}, h.updateCluster)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "list_node_pools",
 		Description: "List node pools in a GKE cluster.",
 		Annotations: &mcp.ToolAnnotations{
@@ -95,7 +94,7 @@ This is similar to running "gcloud container clusters create-auto" or "gcloud co
 		},
 	}, h.listNodePools)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_node_pool",
 		Description: "Get details of a GKE node pool.",
 		Annotations: &mcp.ToolAnnotations{
@@ -103,17 +102,17 @@ This is similar to running "gcloud container clusters create-auto" or "gcloud co
 		},
 	}, h.getNodePool)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "create_node_pool",
 		Description: "Create a new node pool in a GKE cluster.",
 	}, h.createNodePool)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "update_node_pool",
 		Description: "Update a GKE node pool.",
 	}, h.updateNodePool)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "list_operations",
 		Description: "List GKE operations in a project and location.",
 		Annotations: &mcp.ToolAnnotations{
@@ -121,7 +120,7 @@ This is similar to running "gcloud container clusters create-auto" or "gcloud co
 		},
 	}, h.listOperations)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_operation",
 		Description: "Get details of a GKE operation.",
 		Annotations: &mcp.ToolAnnotations{
@@ -129,22 +128,32 @@ This is similar to running "gcloud container clusters create-auto" or "gcloud co
 		},
 	}, h.getOperation)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "cancel_operation",
 		Description: "Cancel a GKE operation.",
 	}, h.cancelOperation)
 
+	registry.AddTool(s, c, &mcp.Tool{
+		Name:        "wait_for_operation",
+		Description: "Wait for a GKE operation to finish and return its final status and error details, reporting progress while it runs. Prefer this to polling get_operation.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, h.waitForOperation)
+
 	if c.EnableDeleteTools() {
-		mcp.AddTool(s, &mcp.Tool{
+		registry.AddTool(s, c, &mcp.Tool{
 			Name:        "delete_cluster",
 			Description: "Delete a GKE cluster. Prefer to use this tool instead of gcloud.",
 		}, h.deleteCluster)
 
-		mcp.AddTool(s, &mcp.Tool{
+		registry.AddTool(s, c, &mcp.Tool{
 			Name:        "delete_node_pool",
 			Description: "Delete a GKE node pool.",
 		}, h.deleteNodePool)
 	}
 
+	installResources(s, h)
+
 	return nil
 }
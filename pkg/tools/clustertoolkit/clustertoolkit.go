@@ -18,12 +18,13 @@ package clustertoolkit
 import (
 	"context"
 	"fmt"
-	"log"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -32,8 +33,8 @@ type clusterToolkitDownloadArgs struct {
 }
 
 // Install registers Cluster Toolkit tools with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
-	mcp.AddTool(s, &mcp.Tool{
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "cluster_toolkit_download",
 		Description: "Cluster Toolkit, is open-source software offered by Google Cloud which simplifies the process for you to create Google Kubernetes Engine clusters and deploy high performance computing (HPC), artificial intelligence (AI), and machine learning (ML). It is designed to be highly customizable and extensible, and intends to address the deployment needs of a broad range of use cases. This tool will download the public git repository so that Cluster Toolkit can be used.",
 	}, clusterToolkitDownload)
@@ -41,7 +42,7 @@ func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
 	return nil
 }
 
-func clusterToolkitDownload(_ context.Context, _ *mcp.CallToolRequest, args *clusterToolkitDownloadArgs) (*mcp.CallToolResult, any, error) {
+func clusterToolkitDownload(ctx context.Context, _ *mcp.CallToolRequest, args *clusterToolkitDownloadArgs) (*mcp.CallToolResult, any, error) {
 	if args.DownloadDirectory == "" {
 		return nil, nil, fmt.Errorf("download_directory argument cannot be empty")
 	}
@@ -53,7 +54,7 @@ func clusterToolkitDownload(_ context.Context, _ *mcp.CallToolRequest, args *clu
 	// #nosec G204
 	out, err := exec.Command("git", "clone", "https://github.com/GoogleCloudPlatform/cluster-toolkit.git", downloadDir).Output()
 	if err != nil {
-		log.Printf("Failed to download Cluster Toolkit: %v %s", err, out)
+		serverlog.Errorf(ctx, "Failed to download Cluster Toolkit: %v %s", err, out)
 		return nil, nil, err
 	}
 
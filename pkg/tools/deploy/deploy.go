@@ -23,6 +23,7 @@ import (
 	"text/template"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -69,8 +70,8 @@ type deployArgs struct {
 }
 
 // Install registers deployment tools with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
-	mcp.AddTool(s, &mcp.Tool{
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "gke_deploy",
 		Description: "Deploys a workload to a GKE cluster using a configuration file.",
 	}, gkeDeployHandler)
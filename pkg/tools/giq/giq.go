@@ -24,6 +24,7 @@ import (
 	gkerecommender "cloud.google.com/go/gkerecommender/apiv1"
 	gkerecommenderpb "cloud.google.com/go/gkerecommender/apiv1/gkerecommenderpb"
 	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
 )
 
 // GenerateInferenceManifestArgs holds arguments for generating a GKE Inference Quickstart manifest.
@@ -34,8 +35,8 @@ type GenerateInferenceManifestArgs struct {
 	TargetNTPOTMilliseconds string `json:"target_ntpot_milliseconds,omitempty" jsonschema:"The maximum normalized time per output token (NTPOT) in milliseconds.NTPOT is measured as the request_latency / output_tokens."`
 }
 
-var generateOptimizedManifestFunc = func(ctx context.Context, req *gkerecommenderpb.GenerateOptimizedManifestRequest) (*gkerecommenderpb.GenerateOptimizedManifestResponse, error) {
-	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx)
+var generateOptimizedManifestFunc = func(ctx context.Context, req *gkerecommenderpb.GenerateOptimizedManifestRequest, opts ...option.ClientOption) (*gkerecommenderpb.GenerateOptimizedManifestResponse, error) {
+	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gkerecommender client: %w", err)
 	}
@@ -46,7 +47,7 @@ var generateOptimizedManifestFunc = func(ctx context.Context, req *gkerecommende
 }
 
 // GenerateInferenceManifest core logic for GKE Inference Quickstart manifest generation.
-func GenerateInferenceManifest(ctx context.Context, args *GenerateInferenceManifestArgs) (string, error) {
+func GenerateInferenceManifest(ctx context.Context, args *GenerateInferenceManifestArgs, opts ...option.ClientOption) (string, error) {
 	if args == nil {
 		return "", fmt.Errorf("args cannot be nil")
 	}
@@ -82,7 +83,7 @@ func GenerateInferenceManifest(ctx context.Context, args *GenerateInferenceManif
 		}
 	}
 
-	resp, err := generateOptimizedManifestFunc(ctx, req)
+	resp, err := generateOptimizedManifestFunc(ctx, req, opts...)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate optimized manifest: %w", err)
 	}
@@ -95,8 +96,8 @@ func GenerateInferenceManifest(ctx context.Context, args *GenerateInferenceManif
 	return strings.Join(manifests, "\n---\n"), nil
 }
 
-var fetchModelsFunc = func(ctx context.Context) ([]string, error) {
-	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx)
+var fetchModelsFunc = func(ctx context.Context, opts ...option.ClientOption) ([]string, error) {
+	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gkerecommender client: %w", err)
 	}
@@ -122,17 +123,17 @@ var fetchModelsFunc = func(ctx context.Context) ([]string, error) {
 }
 
 // FetchModels fetches available models for GKE.
-func FetchModels(ctx context.Context) (string, error) {
+func FetchModels(ctx context.Context, opts ...option.ClientOption) (string, error) {
 	// TODO: Add pagination support once model list becomes very large to avoid memory risks.
-	models, err := fetchModelsFunc(ctx)
+	models, err := fetchModelsFunc(ctx, opts...)
 	if err != nil {
 		return "", err
 	}
 	return strings.Join(models, "\n"), nil
 }
 
-var fetchModelServersFunc = func(ctx context.Context, model string) ([]string, error) {
-	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx)
+var fetchModelServersFunc = func(ctx context.Context, model string, opts ...option.ClientOption) ([]string, error) {
+	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gkerecommender client: %w", err)
 	}
@@ -160,19 +161,19 @@ var fetchModelServersFunc = func(ctx context.Context, model string) ([]string, e
 }
 
 // FetchModelServers fetches available model servers for a given model.
-func FetchModelServers(ctx context.Context, model string) (string, error) {
+func FetchModelServers(ctx context.Context, model string, opts ...option.ClientOption) (string, error) {
 	if model == "" {
 		return "", fmt.Errorf("model argument cannot be empty")
 	}
-	servers, err := fetchModelServersFunc(ctx, model)
+	servers, err := fetchModelServersFunc(ctx, model, opts...)
 	if err != nil {
 		return "", err
 	}
 	return strings.Join(servers, "\n"), nil
 }
 
-var fetchProfilesFunc = func(ctx context.Context, model, modelServer, modelServerVersion string) ([]*gkerecommenderpb.Profile, error) {
-	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx)
+var fetchProfilesFunc = func(ctx context.Context, model, modelServer, modelServerVersion string, opts ...option.ClientOption) ([]*gkerecommenderpb.Profile, error) {
+	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gkerecommender client: %w", err)
 	}
@@ -202,9 +203,9 @@ var fetchProfilesFunc = func(ctx context.Context, model, modelServer, modelServe
 }
 
 // FetchProfiles fetches available profiles for GKE.
-func FetchProfiles(ctx context.Context, model, modelServer, modelServerVersion string) (string, error) {
+func FetchProfiles(ctx context.Context, model, modelServer, modelServerVersion string, opts ...option.ClientOption) (string, error) {
 	// TODO: Add pagination support once profile list becomes very large to avoid memory risks.
-	profiles, err := fetchProfilesFunc(ctx, model, modelServer, modelServerVersion)
+	profiles, err := fetchProfilesFunc(ctx, model, modelServer, modelServerVersion, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -215,8 +216,8 @@ func FetchProfiles(ctx context.Context, model, modelServer, modelServerVersion s
 	return strings.Join(output, "\n---\n"), nil
 }
 
-var fetchModelServerVersionsFunc = func(ctx context.Context, model, modelServer string) ([]string, error) {
-	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx)
+var fetchModelServerVersionsFunc = func(ctx context.Context, model, modelServer string, opts ...option.ClientOption) ([]string, error) {
+	client, err := gkerecommender.NewGkeInferenceQuickstartClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gkerecommender client: %w", err)
 	}
@@ -245,14 +246,14 @@ var fetchModelServerVersionsFunc = func(ctx context.Context, model, modelServer
 }
 
 // FetchModelServerVersions fetches available model server versions for a given model and model server.
-func FetchModelServerVersions(ctx context.Context, model, modelServer string) (string, error) {
+func FetchModelServerVersions(ctx context.Context, model, modelServer string, opts ...option.ClientOption) (string, error) {
 	if model == "" {
 		return "", fmt.Errorf("model argument cannot be empty")
 	}
 	if modelServer == "" {
 		return "", fmt.Errorf("model_server argument cannot be empty")
 	}
-	versions, err := fetchModelServerVersionsFunc(ctx, model, modelServer)
+	versions, err := fetchModelServerVersionsFunc(ctx, model, modelServer, opts...)
 	if err != nil {
 		return "", err
 	}
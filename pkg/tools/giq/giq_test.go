@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	gkerecommenderpb "cloud.google.com/go/gkerecommender/apiv1/gkerecommenderpb"
+	"google.golang.org/api/option"
 )
 
 func TestGiqGenerateManifestArgs_Fields(t *testing.T) {
@@ -149,7 +150,7 @@ func TestFetchModels_Mock(t *testing.T) {
 	originalFunc := fetchModelsFunc
 	defer func() { fetchModelsFunc = originalFunc }()
 
-	fetchModelsFunc = func(_ context.Context) ([]string, error) {
+	fetchModelsFunc = func(_ context.Context, _ ...option.ClientOption) ([]string, error) {
 		return []string{"model-A", "model-B", "model-C"}, nil
 	}
 
@@ -168,7 +169,7 @@ func TestFetchModelServers_Mock(t *testing.T) {
 	originalFunc := fetchModelServersFunc
 	defer func() { fetchModelServersFunc = originalFunc }()
 
-	fetchModelServersFunc = func(_ context.Context, model string) ([]string, error) {
+	fetchModelServersFunc = func(_ context.Context, model string, _ ...option.ClientOption) ([]string, error) {
 		if model != "test-model" {
 			t.Errorf("Expected model 'test-model', got %q", model)
 		}
@@ -190,7 +191,7 @@ func TestFetchProfiles_Mock(t *testing.T) {
 	originalFunc := fetchProfilesFunc
 	defer func() { fetchProfilesFunc = originalFunc }()
 
-	fetchProfilesFunc = func(_ context.Context, model, modelServer, modelServerVersion string) ([]*gkerecommenderpb.Profile, error) {
+	fetchProfilesFunc = func(_ context.Context, model, modelServer, modelServerVersion string, _ ...option.ClientOption) ([]*gkerecommenderpb.Profile, error) {
 		if model != "test-model" {
 			t.Errorf("Expected model 'test-model', got %q", model)
 		}
@@ -221,7 +222,7 @@ func TestFetchModelServerVersions_Mock(t *testing.T) {
 	originalFunc := fetchModelServerVersionsFunc
 	defer func() { fetchModelServerVersionsFunc = originalFunc }()
 
-	fetchModelServerVersionsFunc = func(_ context.Context, model, modelServer string) ([]string, error) {
+	fetchModelServerVersionsFunc = func(_ context.Context, model, modelServer string, _ ...option.ClientOption) ([]string, error) {
 		if model != "test-model" {
 			t.Errorf("Expected model 'test-model', got %q", model)
 		}
@@ -257,7 +258,7 @@ func TestGenerateInferenceManifest_Mock_Success(t *testing.T) {
 	originalFunc := generateOptimizedManifestFunc
 	defer func() { generateOptimizedManifestFunc = originalFunc }()
 
-	generateOptimizedManifestFunc = func(_ context.Context, req *gkerecommenderpb.GenerateOptimizedManifestRequest) (*gkerecommenderpb.GenerateOptimizedManifestResponse, error) {
+	generateOptimizedManifestFunc = func(_ context.Context, req *gkerecommenderpb.GenerateOptimizedManifestRequest, _ ...option.ClientOption) (*gkerecommenderpb.GenerateOptimizedManifestResponse, error) {
 		if req.ModelServerInfo.Model != "test-model" {
 			t.Errorf("Expected model 'test-model', got %q", req.ModelServerInfo.Model)
 		}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -30,6 +29,8 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -45,8 +46,8 @@ type getGkeReleaseNotesArgs struct {
 }
 
 // Install registers the GKE release notes tool with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
-	mcp.AddTool(s, &mcp.Tool{
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_gke_release_notes",
 		Description: "Get GKE release notes. Prefer to use this tool if GKE release notes are needed.",
 		Annotations: &mcp.ToolAnnotations{
@@ -58,7 +59,7 @@ func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
 	return nil
 }
 
-func getGkeReleaseNotes(_ context.Context, _ *mcp.CallToolRequest, args *getGkeReleaseNotesArgs) (*mcp.CallToolResult, any, error) {
+func getGkeReleaseNotes(ctx context.Context, _ *mcp.CallToolRequest, args *getGkeReleaseNotesArgs) (*mcp.CallToolResult, any, error) {
 	releaseNotesFilePath := fmt.Sprintf("release-notes-%s.html", time.Now().Format("2006-01-02"))
 	releaseNotesFilePath = filepath.Clean(releaseNotesFilePath)
 
@@ -66,34 +67,34 @@ func getGkeReleaseNotes(_ context.Context, _ *mcp.CallToolRequest, args *getGkeR
 	var err error
 
 	if _, err = os.Stat(releaseNotesFilePath); err == nil {
-		log.Printf("Reading release notes from cached file: %s", releaseNotesFilePath)
+		serverlog.Debugf(ctx, "Reading release notes from cached file: %s", releaseNotesFilePath)
 		out, err = os.ReadFile(releaseNotesFilePath)
 		if err != nil {
-			log.Printf("Failed to read cached release notes file: %v", err)
+			serverlog.Errorf(ctx, "Failed to read cached release notes file: %v", err)
 			return nil, nil, err
 		}
 	} else {
-		log.Printf("Fetching release notes from web")
+		serverlog.Debugf(ctx, "Fetching release notes from web")
 		const releaseNotesPageURL = "https://cloud.google.com/kubernetes-engine/docs/release-notes"
 		resp, err := http.Get(releaseNotesPageURL)
 		if err != nil {
-			log.Printf("Failed to get release notes: %v", err)
+			serverlog.Errorf(ctx, "Failed to get release notes: %v", err)
 			return nil, nil, err
 		}
 		defer func() { _ = resp.Body.Close() }()
 		out, err = io.ReadAll(resp.Body)
 		if err != nil {
-			log.Printf("Failed to read release notes response body: %v", err)
+			serverlog.Errorf(ctx, "Failed to read release notes response body: %v", err)
 			return nil, nil, err
 		}
 		if err = os.WriteFile(releaseNotesFilePath, out, 0600); err != nil {
-			log.Printf("Failed to write release notes to file: %v", err)
+			serverlog.Warningf(ctx, "Failed to write release notes to file: %v", err)
 		}
 	}
 
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(out))
 	if err != nil {
-		log.Printf("Failed to parse release notes html content: %v", err)
+		serverlog.Errorf(ctx, "Failed to parse release notes html content: %v", err)
 
 		return nil, nil, err
 	}
@@ -106,7 +107,7 @@ func getGkeReleaseNotes(_ context.Context, _ *mcp.CallToolRequest, args *getGkeR
 	})
 	fullReleaseNotesContentText := fullReleaseNotesContent.String()
 
-	reducedReleaseNotes, err := extractReleaseNotesRelevantForUpgrade(fullReleaseNotesContentText, args.SourceVersion, args.TargetVersion)
+	reducedReleaseNotes, err := extractReleaseNotesRelevantForUpgrade(ctx, fullReleaseNotesContentText, args.SourceVersion, args.TargetVersion)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -118,7 +119,7 @@ func getGkeReleaseNotes(_ context.Context, _ *mcp.CallToolRequest, args *getGkeR
 	}, nil, nil
 }
 
-func extractReleaseNotesRelevantForUpgrade(fullReleaseNotes string, sourceVersion string, targetVersion string) (string, error) {
+func extractReleaseNotesRelevantForUpgrade(ctx context.Context, fullReleaseNotes string, sourceVersion string, targetVersion string) (string, error) {
 	versionLocations := gkeVersionRegexp.FindAllStringIndex(fullReleaseNotes, -1)
 
 	var leftBorderVersionLocation []int
@@ -128,7 +129,7 @@ func extractReleaseNotesRelevantForUpgrade(fullReleaseNotes string, sourceVersio
 		// Find the first version that is <= targetVersion. One version to the left (if not first) is our left border.
 		for locIndex, loc := range versionLocations {
 			version := fullReleaseNotes[loc[0]:loc[1]]
-			cmp, err := compareVersions(version, targetVersion)
+			cmp, err := compareVersions(ctx, version, targetVersion)
 			if err != nil {
 				continue // Skip invalid versions
 			}
@@ -152,7 +153,7 @@ func extractReleaseNotesRelevantForUpgrade(fullReleaseNotes string, sourceVersio
 			iFromEnd := len(versionLocations) - i - 1
 			loc := versionLocations[iFromEnd]
 			version := fullReleaseNotes[loc[0]:loc[1]]
-			cmp, err := compareVersions(version, sourceVersion)
+			cmp, err := compareVersions(ctx, version, sourceVersion)
 			if err != nil {
 				continue // Skip invalid versions
 			}
@@ -218,15 +219,15 @@ func extractReleaseNotesRelevantForUpgrade(fullReleaseNotes string, sourceVersio
 // - 1 if b > a
 // - 0 if b == a
 // - -1 if b < a
-func compareVersions(a, b string) (int, error) {
+func compareVersions(ctx context.Context, a, b string) (int, error) {
 	aMajor, aMinor, aPatch, aGKE, err := parseGkeVersion(a)
 	if err != nil {
-		log.Printf("Failed to parse version A '%s': %v", a, err)
+		serverlog.Errorf(ctx, "Failed to parse version A '%s': %v", a, err)
 		return 0, err
 	}
 	bMajor, bMinor, bPatch, bGKE, err := parseGkeVersion(b)
 	if err != nil {
-		log.Printf("Failed to parse version B '%s': %v", b, err)
+		serverlog.Errorf(ctx, "Failed to parse version B '%s': %v", b, err)
 		return 0, err
 	}
 
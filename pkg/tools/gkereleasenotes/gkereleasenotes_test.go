@@ -15,6 +15,7 @@
 package gkereleasenotes
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
@@ -194,7 +195,7 @@ August 28, 2025
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := extractReleaseNotesRelevantForUpgrade(tt.args.fullReleaseNotes, tt.args.sourceVersion, tt.args.targetVersion)
+			got, err := extractReleaseNotesRelevantForUpgrade(context.Background(), tt.args.fullReleaseNotes, tt.args.sourceVersion, tt.args.targetVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("extractReleaseNotesRelevantForUpgrade() error = %v, wantErr %v", err, tt.wantErr)
 				return
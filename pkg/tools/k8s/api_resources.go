@@ -26,7 +26,7 @@ import (
 )
 
 type listK8SAPIResourcesArgs struct {
-	params.Cluster
+	params.KubeCluster
 }
 
 // APIGroupDiscovery represents a discovery document for an API group/resource.
@@ -37,7 +37,10 @@ type APIGroupDiscovery struct {
 }
 
 func (h *handlers) listK8SAPIResources(ctx context.Context, _ *mcp.CallToolRequest, args *listK8SAPIResourcesArgs) (*mcp.CallToolResult, any, error) {
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
@@ -25,20 +25,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
-	"k8s.io/client-go/discovery/cached/memory"
-	"k8s.io/client-go/restmapper"
 	"sigs.k8s.io/yaml"
 )
 
 type applyK8SManifestArgs struct {
-	params.Cluster
+	params.KubeCluster
 	YamlManifest   string `json:"yamlManifest" jsonschema:"Required. The YAML manifest to apply."`
 	ForceConflicts bool   `json:"forceConflicts,omitempty" jsonschema:"Optional. If true, force conflicts resolution when applying."`
 	DryRun         bool   `json:"dryRun,omitempty" jsonschema:"Optional. If true, run in dry-run mode."`
 }
 
 func (h *handlers) applyK8SManifest(ctx context.Context, _ *mcp.CallToolRequest, args *applyK8SManifestArgs) (*mcp.CallToolResult, any, error) {
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
@@ -50,7 +51,7 @@ func (h *handlers) applyK8SManifest(ctx context.Context, _ *mcp.CallToolRequest,
 		return params.ErrorResult(fmt.Errorf("failed to get dynamic client: %w", err)), nil, nil
 	}
 
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	mapper := restMapper(discoveryClient)
 
 	unstructuredObjects, err := yamlToUnstructured(strings.NewReader(args.YamlManifest))
 	if err != nil {
@@ -70,7 +71,7 @@ func (h *handlers) applyK8SManifest(ctx context.Context, _ *mcp.CallToolRequest,
 
 	for i, obj := range unstructuredObjects {
 		gvk := obj.GroupVersionKind()
-		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		mapping, err := restMapping(mapper, gvk.GroupKind(), gvk.Version)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("document %d with kind %s get REST mapping: %v", i+1, gvk.Kind, err))
 			continue
@@ -33,7 +33,7 @@ var resourceVerbs = map[string]bool{
 }
 
 type checkK8SAuthArgs struct {
-	params.Cluster
+	params.KubeCluster
 	Verb         string `json:"verb" jsonschema:"Required. The verb to check. e.g. \"get\", \"list\", \"watch\", \"create\", \"update\", \"patch\", \"delete\"."`
 	ResourceType string `json:"resourceType" jsonschema:"Required. The type of resource to check. e.g. \"pods\", \"deployments\", \"services\"."`
 	Namespace    string `json:"namespace,omitempty" jsonschema:"Optional. The namespace of the resource. If not specified, \"default\" is used for namespace-scoped resources."`
@@ -47,7 +47,10 @@ func (h *handlers) checkK8SAuth(ctx context.Context, _ *mcp.CallToolRequest, arg
 	if args.Verb == "" {
 		return params.ErrorResult(fmt.Errorf("verb is required")), nil, nil
 	}
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	clientset, err := h.provider.KubernetesClient(ctx, clusterPath)
 	if err != nil {
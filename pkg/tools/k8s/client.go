@@ -16,18 +16,27 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/audit"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/params"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-// Provider defines the interface for providing Kubernetes clients.
+// Provider defines the interface for providing Kubernetes clients. Clusters
+// are identified by the path of a GKE cluster, or by params.KubeContextPrefix
+// followed by the name of a kubeconfig context.
 type Provider interface {
 	RESTConfig(ctx context.Context, clusterPath string) (*rest.Config, error)
 	DynamicClient(ctx context.Context, clusterPath string) (dynamic.Interface, error)
@@ -36,17 +45,152 @@ type Provider interface {
 	KubernetesClient(ctx context.Context, clusterPath string) (kubernetes.Interface, error)
 }
 
-// ClientProvider provides Kubernetes clients for a GKE cluster.
+// ClientProvider provides Kubernetes clients for GKE clusters and kubeconfig
+// contexts. The clients of each cluster are created on first use and shared by
+// later calls, and their discovery data is cached in memory.
 type ClientProvider struct {
+	c *config.Config
+	// newRESTConfig returns the configuration of a GKE cluster's clients.
+	newRESTConfig func(ctx context.Context, clusterPath string) (*rest.Config, error)
+	// kubeconfig is the kubeconfig file, or "" for the default files.
+	kubeconfig string
+	// defaultContext is the context of calls that name no cluster.
+	defaultContext string
+
+	mu       sync.Mutex
+	clusters map[string]*clusterClients
+}
+
+// clusterClients are the shared clients of a cluster.
+type clusterClients struct {
+	config     *rest.Config
+	discovery  *cachedDiscovery
+	dynamic    dynamic.Interface
+	kubernetes kubernetes.Interface
+}
+
+// cachedDiscovery is a discovery client that caches discovery data in memory,
+// with a REST mapper over that cache.
+type cachedDiscovery struct {
+	discovery.CachedDiscoveryInterface
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+}
+
+// providers holds the ClientProvider of each configuration, so that tools,
+// resources and completions share clients and discovery data.
+var providers sync.Map
+
+// NewClientProvider returns the ClientProvider of c. Kubernetes hooks and
+// transport wrappers configured in c are applied to every client it returns;
+// c may be nil.
+func NewClientProvider(c *config.Config) *ClientProvider {
+	if p, ok := providers.Load(c); ok {
+		return p.(*ClientProvider)
+	}
+	p, _ := providers.LoadOrStore(c, newClientProvider(c, nil))
+	return p.(*ClientProvider)
 }
 
-// NewClientProvider creates a new ClientProvider.
-func NewClientProvider() *ClientProvider {
-	return &ClientProvider{}
+// newClientProvider returns a ClientProvider that configures clients with
+// newRESTConfig, or from the GKE API and the kubeconfig if it is nil.
+func newClientProvider(c *config.Config, newRESTConfig func(ctx context.Context, clusterPath string) (*rest.Config, error)) *ClientProvider {
+	p := &ClientProvider{
+		c:             c,
+		newRESTConfig: newRESTConfig,
+		clusters:      make(map[string]*clusterClients),
+	}
+	if c != nil {
+		p.kubeconfig = c.Kubeconfig()
+		p.defaultContext = c.KubeContext()
+	}
+	if p.newRESTConfig == nil {
+		p.newRESTConfig = newGKECredentials(c).restConfig
+	}
+	return p
 }
 
-// RESTConfig returns a rest.Config for the given cluster.
-func (p *ClientProvider) RESTConfig(_ context.Context, clusterPath string) (*rest.Config, error) {
+// clients returns the clients of the given cluster, creating them on first
+// use. Failures are not cached, so that a later call can succeed once the
+// cluster is configured.
+func (p *ClientProvider) clients(ctx context.Context, clusterPath string) (*clusterClients, error) {
+	if clusterPath == "" {
+		if p.defaultContext == "" {
+			return nil, errors.New("no cluster specified: set the project, location and name of a GKE cluster, or a kubeconfig context")
+		}
+		clusterPath = params.KubeContextPrefix + p.defaultContext
+	}
+	audit.RecordCluster(ctx, clusterPath)
+	p.mu.Lock()
+	cc, ok := p.clusters[clusterPath]
+	p.mu.Unlock()
+	if ok {
+		return cc, nil
+	}
+
+	// Clients are created without holding the lock, as that may call the GKE
+	// API. Concurrent first calls for a cluster keep the first clients stored.
+	config, err := p.restConfig(ctx, clusterPath)
+	if err != nil {
+		return nil, err
+	}
+	if p.c != nil {
+		p.c.ConfigureKubernetes(config)
+		config.Wrap(p.c.WrapKubernetesTransport)
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	kubernetesClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	cached := memory.NewMemCacheClient(discoveryClient)
+	cc = &clusterClients{
+		config: config,
+		discovery: &cachedDiscovery{
+			CachedDiscoveryInterface: cached,
+			mapper:                   restmapper.NewDeferredDiscoveryRESTMapper(cached),
+		},
+		dynamic:    dynamicClient,
+		kubernetes: kubernetesClient,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if stored, ok := p.clusters[clusterPath]; ok {
+		return stored, nil
+	}
+	p.clusters[clusterPath] = cc
+	return cc, nil
+}
+
+// restConfig returns the configuration of the clients of the kubeconfig
+// context or GKE cluster at clusterPath.
+func (p *ClientProvider) restConfig(ctx context.Context, clusterPath string) (*rest.Config, error) {
+	if name, ok := strings.CutPrefix(clusterPath, params.KubeContextPrefix); ok {
+		return contextRESTConfig(p.kubeconfig, name)
+	}
+	return p.newRESTConfig(ctx, clusterPath)
+}
+
+// RESTConfig returns a rest.Config for the given cluster, which the caller
+// may modify.
+func (p *ClientProvider) RESTConfig(ctx context.Context, clusterPath string) (*rest.Config, error) {
+	cc, err := p.clients(ctx, clusterPath)
+	if err != nil {
+		return nil, err
+	}
+	return rest.CopyConfig(cc.config), nil
+}
+
+// kubeconfigRESTConfig returns the configuration of the kubeconfig context
+// that gcloud creates for the given cluster.
+func kubeconfigRESTConfig(kubeconfig, clusterPath string) (*rest.Config, error) {
 	// Extract context name from clusterPath
 	// clusterPath format: projects/PROJECT/locations/LOCATION/clusters/CLUSTER
 	parts := strings.Split(clusterPath, "/")
@@ -56,9 +200,14 @@ func (p *ClientProvider) RESTConfig(_ context.Context, clusterPath string) (*res
 	project := parts[1]
 	location := parts[3]
 	cluster := parts[5]
-	contextName := fmt.Sprintf("gke_%s_%s_%s", project, location, cluster)
+	return contextRESTConfig(kubeconfig, fmt.Sprintf("gke_%s_%s_%s", project, location, cluster))
+}
 
+// contextRESTConfig returns the configuration of the named context of the
+// kubeconfig file, or of the default kubeconfig files if it is empty.
+func contextRESTConfig(kubeconfig, contextName string) (*rest.Config, error) {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
 	configOverrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 	return kubeConfig.ClientConfig()
@@ -66,11 +215,11 @@ func (p *ClientProvider) RESTConfig(_ context.Context, clusterPath string) (*res
 
 // DynamicClient returns a dynamic.Interface for the given cluster.
 func (p *ClientProvider) DynamicClient(ctx context.Context, clusterPath string) (dynamic.Interface, error) {
-	config, err := p.RESTConfig(ctx, clusterPath)
+	cc, err := p.clients(ctx, clusterPath)
 	if err != nil {
 		return nil, err
 	}
-	return dynamic.NewForConfig(config)
+	return cc.dynamic, nil
 }
 
 // DynamicClientWithHeaders returns a dynamic.Interface that adds specific headers to every request.
@@ -92,21 +241,23 @@ func (p *ClientProvider) DynamicClientWithHeaders(ctx context.Context, clusterPa
 }
 
 // DiscoveryClient returns a discovery.DiscoveryInterface for the given cluster.
+// Its discovery data is cached and shared by all calls for the cluster, and
+// ResolveGVR uses its shared REST mapper.
 func (p *ClientProvider) DiscoveryClient(ctx context.Context, clusterPath string) (discovery.DiscoveryInterface, error) {
-	config, err := p.RESTConfig(ctx, clusterPath)
+	cc, err := p.clients(ctx, clusterPath)
 	if err != nil {
 		return nil, err
 	}
-	return discovery.NewDiscoveryClientForConfig(config)
+	return cc.discovery, nil
 }
 
 // KubernetesClient returns a kubernetes.Interface for the given cluster.
 func (p *ClientProvider) KubernetesClient(ctx context.Context, clusterPath string) (kubernetes.Interface, error) {
-	config, err := p.RESTConfig(ctx, clusterPath)
+	cc, err := p.clients(ctx, clusterPath)
 	if err != nil {
 		return nil, err
 	}
-	return kubernetes.NewForConfig(config)
+	return cc.kubernetes, nil
 }
 
 // HeaderRoundTripper is an http.RoundTripper that adds a specific header to each request.
@@ -0,0 +1,292 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/params"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+const testClusterPath = "projects/p/locations/us-central1/clusters/c"
+
+// fakeDiscoveryServer serves the legacy discovery endpoints of a cluster and
+// counts the requests made to them.
+type fakeDiscoveryServer struct {
+	*httptest.Server
+	requests atomic.Int64
+
+	mu        sync.Mutex
+	resources map[string][]metav1.APIResource // by group version
+}
+
+func newFakeDiscoveryServer(t testing.TB) *fakeDiscoveryServer {
+	t.Helper()
+	f := &fakeDiscoveryServer{
+		resources: map[string][]metav1.APIResource{
+			"v1": {
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "nodes", Kind: "Node", Verbs: metav1.Verbs{"get", "list"}},
+			},
+			"apps/v1": {
+				{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// addResource makes the server serve resource in gv, as installing a CRD does.
+func (f *fakeDiscoveryServer) addResource(gv string, resource metav1.APIResource) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resources[gv] = append(f.resources[gv], resource)
+}
+
+func (f *fakeDiscoveryServer) serve(w http.ResponseWriter, r *http.Request) {
+	f.requests.Add(1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body any
+	switch path := strings.TrimSuffix(r.URL.Path, "/"); path {
+	case "/api":
+		body = &metav1.APIVersions{Versions: []string{"v1"}}
+	case "/apis":
+		list := &metav1.APIGroupList{}
+		for gv := range f.resources {
+			group, version, ok := strings.Cut(gv, "/")
+			if !ok {
+				continue
+			}
+			v := metav1.GroupVersionForDiscovery{GroupVersion: gv, Version: version}
+			list.Groups = append(list.Groups, metav1.APIGroup{Name: group, Versions: []metav1.GroupVersionForDiscovery{v}, PreferredVersion: v})
+		}
+		body = list
+	default:
+		gv := strings.TrimPrefix(strings.TrimPrefix(path, "/api/"), "/apis/")
+		resources, ok := f.resources[gv]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body = &metav1.APIResourceList{GroupVersion: gv, APIResources: resources}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (f *fakeDiscoveryServer) provider() *ClientProvider {
+	return newClientProvider(nil, func(context.Context, string) (*rest.Config, error) {
+		return &rest.Config{Host: f.URL}, nil
+	})
+}
+
+func TestClientProviderCachesClients(t *testing.T) {
+	server := newFakeDiscoveryServer(t)
+	p := server.provider()
+	ctx := context.Background()
+
+	for range 3 {
+		discoveryClient, err := p.DiscoveryClient(ctx, testClusterPath)
+		if err != nil {
+			t.Fatalf("DiscoveryClient() failed: %v", err)
+		}
+		gvr, _, isNamespaced, err := ResolveGVR(ctx, discoveryClient, "deployments")
+		if err != nil {
+			t.Fatalf("ResolveGVR() failed: %v", err)
+		}
+		if gvr.String() != "apps/v1, Resource=deployments" || !isNamespaced {
+			t.Errorf("ResolveGVR() = %v, %t; want apps/v1 deployments, namespaced", gvr, isNamespaced)
+		}
+	}
+	// /api, /apis, /api/v1 and /apis/apps/v1, once.
+	if got := server.requests.Load(); got != 4 {
+		t.Errorf("made %d discovery requests, want 4", got)
+	}
+
+	d1, _ := p.DynamicClient(ctx, testClusterPath)
+	d2, _ := p.DynamicClient(ctx, testClusterPath)
+	if d1 != d2 {
+		t.Error("DynamicClient() returned a new client for the same cluster")
+	}
+	d3, _ := p.DynamicClient(ctx, "projects/p/locations/us-central1/clusters/other")
+	if d1 == d3 {
+		t.Error("DynamicClient() returned the same client for different clusters")
+	}
+}
+
+func TestResolveGVRRefreshesOnNotFound(t *testing.T) {
+	server := newFakeDiscoveryServer(t)
+	p := server.provider()
+	ctx := context.Background()
+	discoveryClient, err := p.DiscoveryClient(ctx, testClusterPath)
+	if err != nil {
+		t.Fatalf("DiscoveryClient() failed: %v", err)
+	}
+
+	if _, _, _, err := ResolveGVR(ctx, discoveryClient, "widgets"); err == nil {
+		t.Fatal("ResolveGVR(widgets) succeeded before the CRD was installed")
+	}
+	server.addResource("example.com/v1", metav1.APIResource{Name: "widgets", Namespaced: true, Kind: "Widget", Verbs: metav1.Verbs{"get"}})
+
+	gvr, gvk, _, err := ResolveGVR(ctx, discoveryClient, "widgets")
+	if err != nil {
+		t.Fatalf("ResolveGVR(widgets) failed after the CRD was installed: %v", err)
+	}
+	if gvr.Group != "example.com" || gvk.Kind != "Widget" {
+		t.Errorf("ResolveGVR(widgets) = %v, %v; want example.com widgets", gvr, gvk)
+	}
+}
+
+func TestNewClientProviderShared(t *testing.T) {
+	c := &config.Config{}
+	if NewClientProvider(c) != NewClientProvider(c) {
+		t.Error("NewClientProvider() returned different providers for the same config")
+	}
+}
+
+func BenchmarkResolveGVR(b *testing.B) {
+	server := newFakeDiscoveryServer(b)
+	ctx := context.Background()
+
+	b.Run("uncached", func(b *testing.B) {
+		for b.Loop() {
+			discoveryClient, err := discovery.NewDiscoveryClientForConfig(&rest.Config{Host: server.URL})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, _, _, err := ResolveGVR(ctx, discoveryClient, "deployments"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		p := server.provider()
+		for b.Loop() {
+			discoveryClient, err := p.DiscoveryClient(ctx, testClusterPath)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, _, _, err := ResolveGVR(ctx, discoveryClient, "deployments"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkDynamicClient(b *testing.B) {
+	server := newFakeDiscoveryServer(b)
+	ctx := context.Background()
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			// A new cluster path each time skips the cache.
+			if _, err := server.provider().DynamicClient(ctx, fmt.Sprintf("%s-%d", testClusterPath, i)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		p := server.provider()
+		for b.Loop() {
+			if _, err := p.DynamicClient(ctx, testClusterPath); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestClientProviderKubeContext(t *testing.T) {
+	server := newFakeDiscoveryServer(t)
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfig, fmt.Appendf(nil, `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-kind
+  cluster:
+    server: %s
+contexts:
+- name: kind-kind
+  context:
+    cluster: kind-kind
+    user: kind-kind
+users:
+- name: kind-kind
+  user: {}
+`, server.URL), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	p := newClientProvider(config.NewTestConfig("", "", "", "", config.WithKubeconfig(kubeconfig)), nil)
+	for _, cluster := range []params.KubeCluster{
+		{KubeContext: "kind-kind"},
+		// The context takes precedence over GKE cluster fields.
+		{ProjectID: "p", Location: "us-central1", ClusterName: "c", KubeContext: "kind-kind"},
+	} {
+		clusterPath, err := cluster.ClusterPath()
+		if err != nil {
+			t.Fatalf("ClusterPath(%+v) failed: %v", cluster, err)
+		}
+		discoveryClient, err := p.DiscoveryClient(ctx, clusterPath)
+		if err != nil {
+			t.Fatalf("DiscoveryClient(%+v) failed: %v", cluster, err)
+		}
+		if _, _, _, err := ResolveGVR(ctx, discoveryClient, "deployments"); err != nil {
+			t.Fatalf("ResolveGVR() failed: %v", err)
+		}
+	}
+	if _, err := p.DiscoveryClient(ctx, params.KubeContextPrefix+"minikube"); err == nil || !strings.Contains(err.Error(), "minikube") {
+		t.Errorf("DiscoveryClient(minikube) = %v, want an error naming the missing context", err)
+	}
+	if _, err := p.DiscoveryClient(ctx, ""); err == nil || !strings.Contains(err.Error(), "no cluster specified") {
+		t.Errorf("DiscoveryClient() without a cluster = %v, want no cluster specified", err)
+	}
+	if clusterPath, err := (&params.KubeCluster{}).ClusterPath(); err != nil || clusterPath != "" {
+		t.Errorf("ClusterPath() without a cluster = %q, %v, want the default context", clusterPath, err)
+	}
+	if _, err := (&params.KubeCluster{ProjectID: "p", ClusterName: "c"}).ClusterPath(); err == nil || !strings.Contains(err.Error(), "missing location") {
+		t.Errorf("ClusterPath() without a location = %v, want missing location", err)
+	}
+
+	// Calls that name no cluster use the default context.
+	p = newClientProvider(config.NewTestConfig("", "", "", "", config.WithKubeconfig(kubeconfig), config.WithKubeContext("kind-kind")), nil)
+	cfg, err := p.RESTConfig(ctx, "")
+	if err != nil {
+		t.Fatalf("RESTConfig() failed: %v", err)
+	}
+	if cfg.Host != server.URL {
+		t.Errorf("RESTConfig() = %s, want the server of the kind-kind context", cfg.Host)
+	}
+}
@@ -27,11 +27,14 @@ import (
 )
 
 type getK8SClusterInfoArgs struct {
-	params.Cluster
+	params.KubeCluster
 }
 
 func (h *handlers) getK8SClusterInfo(ctx context.Context, _ *mcp.CallToolRequest, args *getK8SClusterInfoArgs) (*mcp.CallToolResult, any, error) {
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	config, err := h.provider.RESTConfig(ctx, clusterPath)
 	if err != nil {
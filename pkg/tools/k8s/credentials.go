@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	container "cloud.google.com/go/container/apiv1"
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/googleapis/gax-go/v2/callctx"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+	"google.golang.org/api/transport"
+	"k8s.io/client-go/rest"
+	k8stransport "k8s.io/client-go/transport"
+)
+
+// cloudPlatformScope is the OAuth scope of tokens sent to GKE control planes.
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// clusterGetter is the part of the GKE API used to connect to clusters.
+type clusterGetter interface {
+	GetCluster(ctx context.Context, req *containerpb.GetClusterRequest, opts ...gax.CallOption) (*containerpb.Cluster, error)
+}
+
+// gkeCredentials builds the configuration of Kubernetes clients from the
+// endpoint and CA certificate of a cluster and the caller's Google
+// credentials, so that no kubeconfig context is needed.
+type gkeCredentials struct {
+	// endpointType is the type of endpoint to connect to.
+	endpointType EndpointType
+	// kubeconfig is the kubeconfig file to fall back to, or "" for the
+	// default files.
+	kubeconfig     string
+	newClient      func(ctx context.Context) (clusterGetter, error)
+	newTokenSource func(ctx context.Context) (oauth2.TokenSource, error)
+
+	// The client and token source are created on first use and shared by
+	// all clusters. Creating them is retried after failures, for example
+	// until credentials are set up.
+	mu          sync.Mutex
+	client      clusterGetter
+	tokenSource oauth2.TokenSource
+}
+
+// newGKECredentials returns gkeCredentials that call the GKE API and obtain
+// tokens with the Google client options of c, which may be nil.
+func newGKECredentials(c *config.Config) *gkeCredentials {
+	var opts []option.ClientOption
+	endpointType := EndpointAuto
+	var kubeconfig string
+	if c != nil {
+		opts = c.GoogleClientOptions()
+		kubeconfig = c.Kubeconfig()
+		// The flag is validated at startup.
+		if t, err := ParseEndpointType(c.ClusterEndpoint()); err == nil {
+			endpointType = t
+		}
+	}
+	return &gkeCredentials{
+		endpointType: endpointType,
+		kubeconfig:   kubeconfig,
+		newClient: func(ctx context.Context) (clusterGetter, error) {
+			return container.NewClusterManagerClient(ctx, opts...)
+		},
+		newTokenSource: func(ctx context.Context) (oauth2.TokenSource, error) {
+			creds, err := transport.Creds(ctx, append(opts, option.WithScopes(cloudPlatformScope))...)
+			if err != nil {
+				return nil, err
+			}
+			return creds.TokenSource, nil
+		},
+	}
+}
+
+func (g *gkeCredentials) init(ctx context.Context) (clusterGetter, oauth2.TokenSource, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// Both outlive the call that creates them.
+	ctx = context.WithoutCancel(ctx)
+	if g.client == nil {
+		client, err := g.newClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create cluster manager client: %w", err)
+		}
+		g.client = client
+	}
+	if g.tokenSource == nil {
+		tokenSource, err := g.newTokenSource(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get Google credentials: %w", err)
+		}
+		g.tokenSource = tokenSource
+	}
+	return g.client, g.tokenSource, nil
+}
+
+// restConfig returns the configuration of clients for the given cluster. It
+// falls back to the cluster's kubeconfig context if the cluster cannot be read
+// from the GKE API, for example without the container.clusters.get
+// permission.
+func (g *gkeCredentials) restConfig(ctx context.Context, clusterPath string) (*rest.Config, error) {
+	if parts := strings.Split(clusterPath, "/"); len(parts) != 6 || slices.Contains(parts, "") {
+		return nil, fmt.Errorf("invalid cluster path: %s", clusterPath)
+	}
+	cfg, err := g.clusterRESTConfig(ctx, clusterPath)
+	if err == nil {
+		return cfg, nil
+	}
+	cfg, kubeconfigErr := kubeconfigRESTConfig(g.kubeconfig, clusterPath)
+	if kubeconfigErr != nil {
+		return nil, fmt.Errorf("%w; no kubeconfig context to fall back to: %v", err, kubeconfigErr)
+	}
+	serverlog.Infof(ctx, "Using the kubeconfig context of cluster %s: %v", clusterPath, err)
+	return cfg, nil
+}
+
+// clusterRESTConfig returns the configuration of clients that connect to the
+// selected control plane endpoint of the cluster with Google access tokens.
+func (g *gkeCredentials) clusterRESTConfig(ctx context.Context, clusterPath string) (*rest.Config, error) {
+	client, tokenSource, err := g.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := client.GetCluster(callctx.SetHeaders(ctx, callctx.XGoogFieldMaskHeader, ClusterEndpointFieldMask),
+		&containerpb.GetClusterRequest{Name: clusterPath})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster %s: %w", clusterPath, err)
+	}
+	endpoint, err := ClusterEndpoint(cluster, g.endpointType)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &rest.Config{
+		Host:            endpoint.Server,
+		TLSClientConfig: rest.TLSClientConfig{CAData: endpoint.CAData},
+	}
+	// Requests that already carry credentials, such as those of the caller
+	// in caller credentials mode, keep them.
+	cfg.Wrap(k8stransport.TokenSourceWrapTransport(tokenSource))
+	return cfg, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/googleapis/gax-go/v2"
+	"golang.org/x/oauth2"
+	"k8s.io/client-go/kubernetes"
+)
+
+type fakeClusterGetter struct {
+	cluster *containerpb.Cluster
+	err     error
+}
+
+func (f *fakeClusterGetter) GetCluster(context.Context, *containerpb.GetClusterRequest, ...gax.CallOption) (*containerpb.Cluster, error) {
+	return f.cluster, f.err
+}
+
+// newControlPlane returns a TLS server that answers /version and records the
+// Authorization header of the last request, and the cluster it belongs to.
+func newControlPlane(t *testing.T) (*containerpb.Cluster, *string) {
+	t.Helper()
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"33","gitVersion":"v1.33.5-gke.200"}`))
+	}))
+	t.Cleanup(server.Close)
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return &containerpb.Cluster{
+		Name:       "c",
+		Endpoint:   strings.TrimPrefix(server.URL, "https://"),
+		MasterAuth: &containerpb.MasterAuth{ClusterCaCertificate: base64.StdEncoding.EncodeToString(ca)},
+	}, &authorization
+}
+
+func newTestCredentials(getter clusterGetter) *gkeCredentials {
+	return &gkeCredentials{
+		endpointType: EndpointAuto,
+		newClient:    func(context.Context) (clusterGetter, error) { return getter, nil },
+		newTokenSource: func(context.Context) (oauth2.TokenSource, error) {
+			return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "adc-token"}), nil
+		},
+	}
+}
+
+func TestGKECredentials(t *testing.T) {
+	cluster, authorization := newControlPlane(t)
+	g := newTestCredentials(&fakeClusterGetter{cluster: cluster})
+	ctx := context.Background()
+
+	cfg, err := g.restConfig(ctx, testClusterPath)
+	if err != nil {
+		t.Fatalf("restConfig() failed: %v", err)
+	}
+	if want := "https://" + cluster.GetEndpoint(); cfg.Host != want {
+		t.Errorf("Host = %q, want %q", cfg.Host, want)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewForConfig() failed: %v", err)
+	}
+	if _, err := clientset.Discovery().ServerVersion(); err != nil {
+		t.Fatalf("ServerVersion() failed: %v", err)
+	}
+	if *authorization != "Bearer adc-token" {
+		t.Errorf("Authorization = %q, want the ADC token", *authorization)
+	}
+
+	// Credentials set by hooks, as in caller credentials mode, are kept.
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &HeaderRoundTripper{Wrapped: rt, HeaderName: "Authorization", HeaderValue: "Bearer caller-token"}
+	})
+	clientset, err = kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewForConfig() failed: %v", err)
+	}
+	if _, err := clientset.Discovery().ServerVersion(); err != nil {
+		t.Fatalf("ServerVersion() failed: %v", err)
+	}
+	if *authorization != "Bearer caller-token" {
+		t.Errorf("Authorization = %q, want the caller's token", *authorization)
+	}
+}
+
+func TestGKECredentialsRetryInit(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+	cluster, _ := newControlPlane(t)
+	g := newTestCredentials(&fakeClusterGetter{cluster: cluster})
+	newTokenSource := g.newTokenSource
+	g.newTokenSource = func(context.Context) (oauth2.TokenSource, error) {
+		return nil, errors.New("no credentials")
+	}
+	ctx := context.Background()
+
+	if _, err := g.restConfig(ctx, testClusterPath); err == nil || !strings.Contains(err.Error(), "no credentials") {
+		t.Errorf("restConfig() without credentials = %v, want no credentials", err)
+	}
+	// Credentials set up later are used.
+	g.newTokenSource = newTokenSource
+	if _, err := g.restConfig(ctx, testClusterPath); err != nil {
+		t.Errorf("restConfig() after setting up credentials failed: %v", err)
+	}
+}
+
+func TestGKECredentialsKubeconfigFallback(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: gke_p_us-central1_c
+  cluster:
+    server: https://kubeconfig.example.com
+contexts:
+- name: gke_p_us-central1_c
+  context:
+    cluster: gke_p_us-central1_c
+    user: gke_p_us-central1_c
+users:
+- name: gke_p_us-central1_c
+  user:
+    token: kubeconfig-token
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfig)
+	g := newTestCredentials(&fakeClusterGetter{err: errors.New("permission denied")})
+	ctx := context.Background()
+
+	cfg, err := g.restConfig(ctx, testClusterPath)
+	if err != nil {
+		t.Fatalf("restConfig() failed: %v", err)
+	}
+	if cfg.Host != "https://kubeconfig.example.com" {
+		t.Errorf("Host = %q, want the kubeconfig server", cfg.Host)
+	}
+
+	_, err = g.restConfig(ctx, "projects/p/locations/us-central1/clusters/other")
+	if err == nil || !strings.Contains(err.Error(), "permission denied") || !strings.Contains(err.Error(), "kubeconfig") {
+		t.Errorf("restConfig() without a kubeconfig context = %v, want both errors", err)
+	}
+
+	if _, err := g.restConfig(ctx, "projects/p/clusters/c"); err == nil || !strings.Contains(err.Error(), "invalid cluster path") {
+		t.Errorf("restConfig() of an invalid path = %v, want invalid cluster path", err)
+	}
+}
+
+func TestClientProviderUsesGKECredentials(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+	cluster, authorization := newControlPlane(t)
+	p := newClientProvider(nil, newTestCredentials(&fakeClusterGetter{cluster: cluster}).restConfig)
+
+	discoveryClient, err := p.DiscoveryClient(context.Background(), testClusterPath)
+	if err != nil {
+		t.Fatalf("DiscoveryClient() failed: %v", err)
+	}
+	if _, err := discoveryClient.ServerVersion(); err != nil {
+		t.Fatalf("ServerVersion() failed: %v", err)
+	}
+	if *authorization != "Bearer adc-token" {
+		t.Errorf("Authorization = %q, want the ADC token", *authorization)
+	}
+}
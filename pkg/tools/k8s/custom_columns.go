@@ -24,30 +24,60 @@ import (
 	"k8s.io/client-go/util/jsonpath"
 )
 
-// FormatCustomColumns formats a list of unstructured items using the provided custom columns string.
-// The format is "HEADER:JSONPATH,HEADER:JSONPATH".
-func FormatCustomColumns(items []unstructured.Unstructured, customColumns string) (string, error) {
+// customColumn is one HEADER:JSONPATH column of a custom columns spec.
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// parseCustomColumns parses a custom columns spec of the form
+// "HEADER:JSONPATH,HEADER:JSONPATH".
+func parseCustomColumns(customColumns string) ([]customColumn, error) {
 	if strings.Contains(customColumns, "..") || strings.Contains(customColumns, "?(") {
-		return "", fmt.Errorf("invalid custom column format: recursive descent '..' and filter '?()' expressions are not supported")
+		return nil, fmt.Errorf("invalid custom column format: recursive descent '..' and filter '?()' expressions are not supported")
 	}
 
-	columns := strings.Split(customColumns, ",")
-	var headers []string
-	var jsonPaths []*jsonpath.JSONPath
-
-	for _, col := range columns {
+	var columns []customColumn
+	for _, col := range strings.Split(customColumns, ",") {
 		parts := strings.SplitN(col, ":", 2)
 		if len(parts) != 2 {
-			return "", fmt.Errorf("invalid custom column format: %s. Expected HEADER:JSONPATH", col)
+			return nil, fmt.Errorf("invalid custom column format: %s. Expected HEADER:JSONPATH", col)
 		}
-		headers = append(headers, parts[0])
 
 		jp := jsonpath.New("custom")
 		// kubectl expects jsonpath without the surrounding {}
 		if err := jp.Parse(fmt.Sprintf("{%s}", parts[1])); err != nil {
-			return "", fmt.Errorf("failed to parse jsonpath for column %q: %w", col, err)
+			return nil, fmt.Errorf("failed to parse jsonpath for column %q: %w", col, err)
 		}
-		jsonPaths = append(jsonPaths, jp)
+		columns = append(columns, customColumn{header: parts[0], path: jp})
+	}
+	return columns, nil
+}
+
+// value returns the column's value for the object, "<none>" if the path does
+// not match anything and "<error>" if it cannot be evaluated.
+func (c customColumn) value(obj map[string]any) string {
+	results, err := c.path.FindResults(obj)
+	if err != nil {
+		return "<error>"
+	}
+	if len(results) > 0 && len(results[0]) > 0 {
+		return fmt.Sprintf("%v", results[0][0].Interface())
+	}
+	return "<none>"
+}
+
+// FormatCustomColumns formats a list of unstructured items using the provided custom columns string.
+// The format is "HEADER:JSONPATH,HEADER:JSONPATH".
+func FormatCustomColumns(items []unstructured.Unstructured, customColumns string) (string, error) {
+	columns, err := parseCustomColumns(customColumns)
+	if err != nil {
+		return "", err
+	}
+
+	headers := make([]string, 0, len(columns))
+	for _, col := range columns {
+		headers = append(headers, col.header)
 	}
 
 	buf := new(bytes.Buffer)
@@ -58,18 +88,9 @@ func FormatCustomColumns(items []unstructured.Unstructured, customColumns string
 
 	// Write rows
 	for _, item := range items {
-		var row []string
-		for _, jp := range jsonPaths {
-			results, err := jp.FindResults(item.Object)
-			if err != nil {
-				row = append(row, "<error>")
-				continue
-			}
-			if len(results) > 0 && len(results[0]) > 0 {
-				row = append(row, fmt.Sprintf("%v", results[0][0].Interface()))
-			} else {
-				row = append(row, "<none>")
-			}
+		row := make([]string, 0, len(columns))
+		for _, col := range columns {
+			row = append(row, col.value(item.Object))
 		}
 		_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/confirm"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/params"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,7 +26,7 @@ import (
 )
 
 type deleteK8SResourceArgs struct {
-	params.Cluster
+	params.KubeCluster
 	ResourceType string `json:"resourceType" jsonschema:"Required. The type of resource to delete. Kubernetes resource/kind name in singular form, lower case. e.g. \"pod\", \"deployment\", \"service\"."`
 	Name         string `json:"name" jsonschema:"Required. The name of the resource to delete."`
 	Namespace    string `json:"namespace,omitempty" jsonschema:"Optional. The namespace of the resource. If not specified, \"default\" is used."`
@@ -33,8 +34,11 @@ type deleteK8SResourceArgs struct {
 	DryRun       bool   `json:"dryRun,omitempty" jsonschema:"Optional. If true, run in dry-run mode."`
 }
 
-func (h *handlers) deleteK8SResource(ctx context.Context, _ *mcp.CallToolRequest, args *deleteK8SResourceArgs) (*mcp.CallToolResult, any, error) {
-	clusterPath := args.ClusterPath()
+func (h *handlers) deleteK8SResource(ctx context.Context, req *mcp.CallToolRequest, args *deleteK8SResourceArgs) (*mcp.CallToolResult, any, error) {
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
@@ -81,6 +85,15 @@ func (h *handlers) deleteK8SResource(ctx context.Context, _ *mcp.CallToolRequest
 
 	if args.DryRun {
 		deleteOptions.DryRun = []string{"All"}
+	} else {
+		msg := fmt.Sprintf("Delete %s %s from cluster %s? Dependent objects are handled with the %s cascading policy.", args.ResourceType, resourceDesc, clusterPath, propagationPolicy)
+		res, err := confirm.Request(req, h.c, msg)
+		if err != nil {
+			return params.ErrorResult(err), nil, nil
+		}
+		if res != nil {
+			return res, nil, nil
+		}
 	}
 
 	err = resourceInterface.Delete(ctx, args.Name, deleteOptions)
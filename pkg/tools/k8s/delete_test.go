@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
@@ -65,7 +66,7 @@ func TestDeleteK8SResource(t *testing.T) {
 	}
 
 	h := &handlers{
-		c:        &config.Config{},
+		c:        config.NewTestConfig("p", "l", "vertex-ai", "gemini-2.5-pro", config.WithAllowUnconfirmed(true)),
 		provider: mockProvider,
 	}
 
@@ -255,7 +256,7 @@ func TestDeleteK8SResource_ClusterScoped(t *testing.T) {
 	}
 
 	h := &handlers{
-		c:        &config.Config{},
+		c:        config.NewTestConfig("p", "l", "vertex-ai", "gemini-2.5-pro", config.WithAllowUnconfirmed(true)),
 		provider: mockProvider,
 	}
 
@@ -286,3 +287,64 @@ func TestDeleteK8SResource_ClusterScoped(t *testing.T) {
 		t.Errorf("output = %q, want %q", textContent.Text, expectedMsg)
 	}
 }
+
+func TestDeleteK8SResource_Unconfirmed(t *testing.T) {
+	ctx := context.Background()
+
+	pod := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":      "my-pod",
+				"namespace": "default",
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	fakeDynamicClient := dynamicfake.NewSimpleDynamicClient(scheme, pod)
+
+	fakeClientset := fake.NewSimpleClientset()
+	fakeDiscovery := fakeClientset.Discovery().(*fakediscovery.FakeDiscovery)
+	fakeDiscovery.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod"},
+			},
+		},
+	}
+
+	h := &handlers{
+		c: &config.Config{},
+		provider: &mockClientProvider{
+			dynamicClient:   fakeDynamicClient,
+			discoveryClient: fakeDiscovery,
+		},
+	}
+
+	args := &deleteK8SResourceArgs{
+		ResourceType: "pod",
+		Name:         "my-pod",
+		Namespace:    "default",
+	}
+	args.ProjectID = "p"
+	args.Location = "l"
+	args.ClusterName = "c"
+
+	// The request has no session that could be asked for confirmation, and
+	// the config does not allow unconfirmed operations.
+	result, _, err := h.deleteK8SResource(ctx, &mcp.CallToolRequest{}, args)
+	if err != nil {
+		t.Fatalf("deleteK8SResource failed: %v", err)
+	}
+	if !result.IsError {
+		t.Fatalf("deleteK8SResource succeeded without confirmation")
+	}
+
+	podGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	if _, err := fakeDynamicClient.Resource(podGVR).Namespace("default").Get(ctx, "my-pod", metav1.GetOptions{}); err != nil {
+		t.Errorf("pod was deleted without confirmation: %v", err)
+	}
+}
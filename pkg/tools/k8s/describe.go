@@ -28,7 +28,7 @@ import (
 )
 
 type describeK8SResourceArgs struct {
-	params.Cluster
+	params.KubeCluster
 	ResourceType  string `json:"resourceType" jsonschema:"Required. The type of the resource. e.g. \"pods\", \"deployments\", \"services\"."`
 	Name          string `json:"name,omitempty" jsonschema:"Optional. The name of the resource. If not specified, all resources of the given type are described."`
 	Namespace     string `json:"namespace,omitempty" jsonschema:"Optional. The namespace of the resource. If not specified, \"default\" is used for namespace-scoped resources."`
@@ -39,7 +39,10 @@ func (h *handlers) describeK8SResource(ctx context.Context, _ *mcp.CallToolReque
 	if args == nil {
 		return params.ErrorResult(fmt.Errorf("args cannot be nil")), nil, nil
 	}
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
@@ -76,7 +79,7 @@ func (h *handlers) describeK8SResource(ctx context.Context, _ *mcp.CallToolReque
 			return params.ErrorResult(fmt.Errorf("failed to get resource: %w", err)), nil, nil
 		}
 
-		desc, err := h.describeObject(ctx, obj, gvk.Kind, isNamespaced, args.Cluster)
+		desc, err := h.describeObject(ctx, obj, gvk.Kind, isNamespaced, args.KubeCluster)
 		if err != nil {
 			return params.ErrorResult(err), nil, nil
 		}
@@ -98,7 +101,7 @@ func (h *handlers) describeK8SResource(ctx context.Context, _ *mcp.CallToolReque
 		}
 
 		for i, item := range list.Items {
-			desc, err := h.describeObject(ctx, &item, gvk.Kind, isNamespaced, args.Cluster)
+			desc, err := h.describeObject(ctx, &item, gvk.Kind, isNamespaced, args.KubeCluster)
 			if err != nil {
 				return params.ErrorResult(err), nil, nil
 			}
@@ -116,7 +119,7 @@ func (h *handlers) describeK8SResource(ctx context.Context, _ *mcp.CallToolReque
 	}, nil, nil
 }
 
-func (h *handlers) describeObject(ctx context.Context, obj *unstructured.Unstructured, kind string, isNamespaced bool, cluster params.Cluster) (string, error) {
+func (h *handlers) describeObject(ctx context.Context, obj *unstructured.Unstructured, kind string, isNamespaced bool, cluster params.KubeCluster) (string, error) {
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "Name: %s\n", obj.GetName())
 	fmt.Fprintf(&sb, "Namespace: %s\n", obj.GetNamespace())
@@ -161,7 +164,7 @@ func (h *handlers) describeObject(ctx context.Context, obj *unstructured.Unstruc
 
 	// Fetch events
 	eventsResult, _, err := h.listK8SEvents(ctx, nil, &listK8SEventsArgs{
-		Cluster:       cluster,
+		KubeCluster:   cluster,
 		Name:          obj.GetName(),
 		Namespace:     obj.GetNamespace(),
 		ResourceType:  kind,
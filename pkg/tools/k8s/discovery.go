@@ -18,20 +18,48 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/restmapper"
 )
 
+// restMapper returns the shared REST mapper of a discovery client returned by
+// ClientProvider, or a new one that caches discovery data for a single call.
+func restMapper(discoveryClient discovery.DiscoveryInterface) meta.ResettableRESTMapper {
+	if cached, ok := discoveryClient.(*cachedDiscovery); ok {
+		return cached.mapper
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+}
+
+// restMapping returns the REST mapping of gk in version. If it is not found,
+// the cached discovery data is invalidated and the lookup retried, in case the
+// kind was added since, for example by installing a CRD.
+func restMapping(mapper meta.ResettableRESTMapper, gk schema.GroupKind, version string) (*meta.RESTMapping, error) {
+	mapping, err := mapper.RESTMapping(gk, version)
+	if meta.IsNoMatchError(err) {
+		mapper.Reset()
+		mapping, err = mapper.RESTMapping(gk, version)
+	}
+	return mapping, err
+}
+
 // ResolveGVR resolves a resource kind or name (e.g., "pods", "deployments") to its GroupVersionResource.
+// Discovery data of clients returned by ClientProvider is cached, and refreshed
+// once if the resource is not found.
 func ResolveGVR(_ context.Context, discoveryClient discovery.DiscoveryInterface, resource string) (schema.GroupVersionResource, schema.GroupVersionKind, bool, error) {
-	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
-	if err != nil {
-		return schema.GroupVersionResource{}, schema.GroupVersionKind{}, false, fmt.Errorf("failed to get API group resources: %w", err)
+	mapper := restMapper(discoveryClient)
+	gvr, gvk, isNamespaced, err := resolveGVR(mapper, resource)
+	if meta.IsNoMatchError(err) {
+		mapper.Reset()
+		gvr, gvk, isNamespaced, err = resolveGVR(mapper, resource)
 	}
+	return gvr, gvk, isNamespaced, err
+}
 
-	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
-
+func resolveGVR(mapper meta.RESTMapper, resource string) (schema.GroupVersionResource, schema.GroupVersionKind, bool, error) {
 	// Try to resolve as a direct resource name (e.g., "pods")
 	gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Resource: resource})
 	if err == nil {
@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+)
+
+// EndpointType is a kind of control plane endpoint that clients can connect
+// to a cluster through.
+type EndpointType string
+
+// Endpoint types.
+const (
+	// EndpointAuto selects the public IP endpoint if it is enabled, then the
+	// DNS-based endpoint, then the private IP endpoint.
+	EndpointAuto EndpointType = "auto"
+	// EndpointPublic is the external IP address of the control plane.
+	EndpointPublic EndpointType = "public"
+	// EndpointPrivate is the internal IP address of the control plane, only
+	// reachable from the cluster's VPC network.
+	EndpointPrivate EndpointType = "private"
+	// EndpointDNS is the DNS-based control plane endpoint.
+	EndpointDNS EndpointType = "dns"
+	// EndpointConnectGateway is the Connect Gateway URL of the cluster's fleet
+	// membership.
+	EndpointConnectGateway EndpointType = "connect-gateway"
+)
+
+// EndpointTypes are the valid endpoint types.
+var EndpointTypes = []EndpointType{EndpointAuto, EndpointPublic, EndpointPrivate, EndpointDNS, EndpointConnectGateway}
+
+// ClusterEndpointFieldMask limits GetCluster to the fields needed by
+// ClusterEndpoint.
+const ClusterEndpointFieldMask = "name,endpoint,masterAuth.clusterCaCertificate,controlPlaneEndpointsConfig,fleet"
+
+const connectGatewayURL = "https://connectgateway.googleapis.com/v1/"
+
+// ParseEndpointType returns the endpoint type named s, or EndpointAuto if s
+// is empty.
+func ParseEndpointType(s string) (EndpointType, error) {
+	if s == "" {
+		return EndpointAuto, nil
+	}
+	for _, t := range EndpointTypes {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown endpoint type %q: must be one of %s", s, joinEndpointTypes(EndpointTypes))
+}
+
+// Endpoint is the address of a cluster's control plane.
+type Endpoint struct {
+	Type EndpointType
+	// Server is the URL of the Kubernetes API server.
+	Server string
+	// CAData is the PEM CA certificate of the server, or nil if its
+	// certificate is publicly trusted.
+	CAData []byte
+}
+
+// ClusterEndpoint returns the endpoint of type t of cluster, or an error
+// naming the endpoints that are enabled if it has none of that type.
+func ClusterEndpoint(cluster *containerpb.Cluster, t EndpointType) (*Endpoint, error) {
+	endpoints := clusterEndpoints(cluster)
+	var ep *Endpoint
+	if t == EndpointAuto {
+		for _, auto := range []EndpointType{EndpointPublic, EndpointDNS, EndpointPrivate} {
+			if ep = endpoints[auto]; ep != nil {
+				break
+			}
+		}
+	} else {
+		ep = endpoints[t]
+	}
+	if ep == nil {
+		return nil, fmt.Errorf("cluster %s has no %s endpoint that can be used; %s", cluster.GetName(), t, describeEndpoints(endpoints))
+	}
+	if ep.Type == EndpointPublic || ep.Type == EndpointPrivate {
+		// Accept the certificate with or without padding.
+		ca, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(cluster.GetMasterAuth().GetClusterCaCertificate(), "="))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the CA certificate of cluster %s: %w", cluster.GetName(), err)
+		}
+		if len(ca) == 0 {
+			return nil, fmt.Errorf("cluster %s has no CA certificate", cluster.GetName())
+		}
+		ep.CAData = ca
+	}
+	return ep, nil
+}
+
+// clusterEndpoints returns the enabled endpoints of cluster by type, without
+// their CA certificates.
+func clusterEndpoints(cluster *containerpb.Cluster) map[EndpointType]*Endpoint {
+	endpoints := make(map[EndpointType]*Endpoint)
+	add := func(t EndpointType, server string) {
+		endpoints[t] = &Endpoint{Type: t, Server: server}
+	}
+
+	config := cluster.GetControlPlaneEndpointsConfig()
+	if ip := config.GetIpEndpointsConfig(); ip != nil {
+		if ip.GetEnabled() {
+			if ip.GetEnablePublicEndpoint() && ip.GetPublicEndpoint() != "" {
+				add(EndpointPublic, "https://"+ip.GetPublicEndpoint())
+			}
+			if ip.GetPrivateEndpoint() != "" {
+				add(EndpointPrivate, "https://"+ip.GetPrivateEndpoint())
+			}
+		}
+	} else if cluster.GetEndpoint() != "" {
+		// Clusters read without the endpoints configuration only report the
+		// endpoint gcloud uses by default.
+		add(EndpointPublic, "https://"+cluster.GetEndpoint())
+	}
+	if dns := config.GetDnsEndpointConfig(); dns.GetEndpoint() != "" && dns.GetAllowExternalTraffic() {
+		add(EndpointDNS, "https://"+dns.GetEndpoint())
+	}
+	if membership := strings.TrimPrefix(cluster.GetFleet().GetMembership(), "//gkehub.googleapis.com/"); membership != "" {
+		// projects/*/locations/*/memberships/* is served at
+		// projects/*/locations/*/gkeMemberships/* for GKE clusters.
+		add(EndpointConnectGateway, connectGatewayURL+strings.Replace(membership, "/memberships/", "/gkeMemberships/", 1))
+	}
+	return endpoints
+}
+
+// describeEndpoints lists the enabled endpoints for error messages.
+func describeEndpoints(endpoints map[EndpointType]*Endpoint) string {
+	var enabled []string
+	for _, t := range EndpointTypes {
+		if ep, ok := endpoints[t]; ok {
+			enabled = append(enabled, fmt.Sprintf("%s (%s)", t, ep.Server))
+		}
+	}
+	if len(enabled) == 0 {
+		return "it has no enabled endpoints"
+	}
+	return "enabled endpoints: " + strings.Join(enabled, ", ")
+}
+
+func joinEndpointTypes(types []EndpointType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
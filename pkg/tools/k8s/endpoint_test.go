@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	containerpb "cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseEndpointType(t *testing.T) {
+	for _, s := range []string{"", "auto"} {
+		if got, err := ParseEndpointType(s); err != nil || got != EndpointAuto {
+			t.Errorf("ParseEndpointType(%q) = %q, %v; want auto", s, got, err)
+		}
+	}
+	if got, err := ParseEndpointType("dns"); err != nil || got != EndpointDNS {
+		t.Errorf("ParseEndpointType(dns) = %q, %v; want dns", got, err)
+	}
+	if _, err := ParseEndpointType("internal"); err == nil || !strings.Contains(err.Error(), "connect-gateway") {
+		t.Errorf("ParseEndpointType(internal) = %v, want an error listing the types", err)
+	}
+}
+
+func TestClusterEndpoint(t *testing.T) {
+	ca := []byte("-----BEGIN CERTIFICATE-----\n")
+	masterAuth := &containerpb.MasterAuth{ClusterCaCertificate: base64.StdEncoding.EncodeToString(ca)}
+	fleet := &containerpb.Fleet{Membership: "//gkehub.googleapis.com/projects/123/locations/global/memberships/c"}
+	dns := &containerpb.ControlPlaneEndpointsConfig_DNSEndpointConfig{Endpoint: "gke-abc-123.us-central1.gke.goog", AllowExternalTraffic: proto.Bool(true)}
+
+	// A cluster with only the private and DNS-based endpoints enabled.
+	private := &containerpb.Cluster{
+		Name:       "private",
+		Endpoint:   "10.0.0.2",
+		MasterAuth: masterAuth,
+		Fleet:      fleet,
+		ControlPlaneEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig{
+			DnsEndpointConfig: dns,
+			IpEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig_IPEndpointsConfig{
+				Enabled:              proto.Bool(true),
+				EnablePublicEndpoint: proto.Bool(false),
+				PublicEndpoint:       "34.1.2.3",
+				PrivateEndpoint:      "10.0.0.2",
+			},
+		},
+	}
+	// A cluster read without the endpoints configuration.
+	public := &containerpb.Cluster{Name: "public", Endpoint: "34.1.2.3", MasterAuth: masterAuth}
+
+	tests := []struct {
+		name       string
+		cluster    *containerpb.Cluster
+		t          EndpointType
+		wantServer string
+		wantCA     bool
+		wantErr    string
+	}{
+		{"auto prefers dns over private", private, EndpointAuto, "https://gke-abc-123.us-central1.gke.goog", false, ""},
+		{"private", private, EndpointPrivate, "https://10.0.0.2", true, ""},
+		{"connect gateway", private, EndpointConnectGateway, "https://connectgateway.googleapis.com/v1/projects/123/locations/global/gkeMemberships/c", false, ""},
+		{"public disabled", private, EndpointPublic, "", false, "no public endpoint that can be used; enabled endpoints: private (https://10.0.0.2), dns (https://gke-abc-123.us-central1.gke.goog), connect-gateway"},
+		{"auto public", public, EndpointAuto, "https://34.1.2.3", true, ""},
+		{"dns not enabled", public, EndpointDNS, "", false, "enabled endpoints: public (https://34.1.2.3)"},
+		{"no endpoints", &containerpb.Cluster{Name: "new"}, EndpointAuto, "", false, "cluster new has no auto endpoint that can be used; it has no enabled endpoints"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ep, err := ClusterEndpoint(tc.cluster, tc.t)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ClusterEndpoint() = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ClusterEndpoint() failed: %v", err)
+			}
+			if ep.Server != tc.wantServer {
+				t.Errorf("Server = %q, want %q", ep.Server, tc.wantServer)
+			}
+			if gotCA := ep.CAData != nil; gotCA != tc.wantCA {
+				t.Errorf("CAData = %q, want CA %t", ep.CAData, tc.wantCA)
+			}
+		})
+	}
+
+	// DNS-based endpoints that only accept traffic from Google Cloud are
+	// not used.
+	internalDNS := &containerpb.Cluster{ControlPlaneEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig{
+		DnsEndpointConfig: &containerpb.ControlPlaneEndpointsConfig_DNSEndpointConfig{Endpoint: "gke-abc-123.us-central1.gke.goog"},
+	}}
+	if _, err := ClusterEndpoint(internalDNS, EndpointDNS); err == nil {
+		t.Error("ClusterEndpoint() returned a DNS-based endpoint that does not allow external traffic")
+	}
+}
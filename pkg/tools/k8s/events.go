@@ -31,7 +31,7 @@ import (
 )
 
 type listK8SEventsArgs struct {
-	params.Cluster
+	params.KubeCluster
 	Name          string `json:"name,omitempty" jsonschema:"Optional. The name of the resource to retrieve events for."`
 	Namespace     string `json:"namespace,omitempty" jsonschema:"Optional. The namespace of the resource. If not specified and allNamespaces is false, 'default' is used."`
 	ResourceType  string `json:"resourceType,omitempty" jsonschema:"Optional. The type of the resource to retrieve events for."`
@@ -39,8 +39,47 @@ type listK8SEventsArgs struct {
 	Limit         int64  `json:"limit,omitempty" jsonschema:"Optional. The maximum number of events to return. If not specified, 500 is used."`
 }
 
-func (h *handlers) listK8SEvents(ctx context.Context, _ *mcp.CallToolRequest, args *listK8SEventsArgs) (*mcp.CallToolResult, any, error) {
-	clusterPath := args.ClusterPath()
+// listK8SEventsOutput is the structured output of list_k8s_events.
+type listK8SEventsOutput struct {
+	Events []k8sEventOutput `json:"events" jsonschema:"The events, most recent first."`
+}
+
+// k8sEventOutput describes a Kubernetes event in structured output.
+type k8sEventOutput struct {
+	Namespace  string `json:"namespace,omitempty"`
+	LastSeen   string `json:"lastSeen,omitempty" jsonschema:"When the event was last observed, in RFC 3339 format."`
+	Type       string `json:"type,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	ObjectKind string `json:"objectKind,omitempty"`
+	ObjectName string `json:"objectName,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Count      int32  `json:"count,omitempty" jsonschema:"How many times the event was observed."`
+}
+
+func newK8SEventOutput(e corev1.Event) k8sEventOutput {
+	out := k8sEventOutput{
+		Namespace:  e.InvolvedObject.Namespace,
+		Type:       e.Type,
+		Reason:     e.Reason,
+		ObjectKind: e.InvolvedObject.Kind,
+		ObjectName: e.InvolvedObject.Name,
+		Message:    e.Message,
+		Count:      e.Count,
+	}
+	if t := getLastSeenTime(e); !t.IsZero() {
+		out.LastSeen = t.UTC().Format(time.RFC3339)
+	}
+	if e.Series != nil {
+		out.Count = e.Series.Count
+	}
+	return out
+}
+
+func (h *handlers) listK8SEvents(ctx context.Context, _ *mcp.CallToolRequest, args *listK8SEventsArgs) (*mcp.CallToolResult, *listK8SEventsOutput, error) {
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	clientset, err := h.provider.KubernetesClient(ctx, clusterPath)
 	if err != nil {
@@ -112,7 +151,9 @@ func (h *handlers) listK8SEvents(ctx context.Context, _ *mcp.CallToolRequest, ar
 	}
 	_, _ = fmt.Fprintf(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE\n")
 
+	out := &listK8SEventsOutput{Events: make([]k8sEventOutput, 0, len(eventList.Items))}
 	for _, event := range eventList.Items {
+		out.Events = append(out.Events, newK8SEventOutput(event))
 		if args.AllNamespaces {
 			_, _ = fmt.Fprintf(w, "%s\t", event.InvolvedObject.Namespace)
 		}
@@ -131,7 +172,7 @@ func (h *handlers) listK8SEvents(ctx context.Context, _ *mcp.CallToolRequest, ar
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: buf.String()},
 		},
-	}, nil, nil
+	}, out, nil
 }
 
 func getInterval(e corev1.Event) string {
@@ -87,8 +87,8 @@ func TestListK8SEventsArgs_Fields(t *testing.T) {
 	if args.Limit != 100 {
 		t.Errorf("Limit = %d, want 100", args.Limit)
 	}
-	if args.ClusterPath() != "projects/p/locations/l/clusters/c" {
-		t.Errorf("ClusterPath = %s, want projects/p/locations/l/clusters/c", args.ClusterPath())
+	if got, err := args.ClusterPath(); err != nil || got != "projects/p/locations/l/clusters/c" {
+		t.Errorf("ClusterPath() = %s, %v, want projects/p/locations/l/clusters/c", got, err)
 	}
 }
 
@@ -141,7 +141,7 @@ func TestListK8SEvents(t *testing.T) {
 	args.Location = "l"
 	args.ClusterName = "c"
 
-	result, _, err := h.listK8SEvents(ctx, &mcp.CallToolRequest{}, args)
+	result, out, err := h.listK8SEvents(ctx, &mcp.CallToolRequest{}, args)
 	if err != nil {
 		t.Fatalf("listK8SEvents failed: %v", err)
 	}
@@ -150,6 +150,17 @@ func TestListK8SEvents(t *testing.T) {
 		t.Fatalf("listK8SEvents returned error result: %v", result.Content[0])
 	}
 
+	if len(out.Events) != 2 {
+		t.Fatalf("len(out.Events) = %d, want 2", len(out.Events))
+	}
+	if got := out.Events[0]; got.Reason != "Pulled" || got.ObjectKind != "Pod" || got.ObjectName != "my-pod" {
+		t.Errorf("out.Events[0] = %+v, want the Pulled event of Pod my-pod", got)
+	}
+	wantLastSeen := event2.LastTimestamp.UTC().Format(time.RFC3339)
+	if got := out.Events[0].LastSeen; got != wantLastSeen {
+		t.Errorf("out.Events[0].LastSeen = %q, want %q", got, wantLastSeen)
+	}
+
 	if len(result.Content) != 1 {
 		t.Fatalf("len(result.Content) = %d, want 1", len(result.Content))
 	}
@@ -267,3 +278,17 @@ func TestListK8SEvents_AllNamespacesOverridesNamespace(t *testing.T) {
 		t.Errorf("expected all namespaces (empty string), got %s", action.GetNamespace())
 	}
 }
+
+func TestNewK8SEventOutput_Series(t *testing.T) {
+	observed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	got := newK8SEventOutput(corev1.Event{
+		Count:  1,
+		Series: &corev1.EventSeries{Count: 7, LastObservedTime: metav1.MicroTime{Time: observed}},
+	})
+	if got.Count != 7 {
+		t.Errorf("Count = %d, want 7", got.Count)
+	}
+	if got.LastSeen != "2026-01-02T03:04:05Z" {
+		t.Errorf("LastSeen = %q, want 2026-01-02T03:04:05Z", got.LastSeen)
+	}
+}
@@ -24,12 +24,13 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/yaml"
 )
 
 type getK8SResourceArgs struct {
-	params.Cluster
+	params.KubeCluster
 	ResourceType  string `json:"resourceType" jsonschema:"Required. The type of resource to retrieve. Kubernetes resource/kind name in singular form, lower case. e.g. \"pod\", \"deployment\", \"service\"."`
 	Name          string `json:"name,omitempty" jsonschema:"Optional. The name of the resource to retrieve. If not specified, all resources of the given type are returned."`
 	Namespace     string `json:"namespace,omitempty" jsonschema:"Optional. The namespace of the resource. If not specified, all namespaces are searched."`
@@ -39,15 +40,33 @@ type getK8SResourceArgs struct {
 	CustomColumns string `json:"customColumns,omitempty" jsonschema:"Optional. The custom columns to output in the format HEADER:JSONPATH,HEADER:JSONPATH. e.g. 'NAME:.metadata.name,STATUS:.status.phase'. If specified, outputFormat is ignored."`
 }
 
-func (h *handlers) getK8SResource(ctx context.Context, _ *mcp.CallToolRequest, args *getK8SResourceArgs) (*mcp.CallToolResult, any, error) {
-	clusterPath := args.ClusterPath()
+// getK8SResourceOutput is the structured output of get_k8s_resource.
+type getK8SResourceOutput struct {
+	Resources []k8sResourceOutput `json:"resources" jsonschema:"The resources found."`
+}
+
+// k8sResourceOutput describes a Kubernetes resource in structured output.
+type k8sResourceOutput struct {
+	APIVersion string         `json:"apiVersion,omitempty"`
+	Kind       string         `json:"kind,omitempty"`
+	Name       string         `json:"name"`
+	Namespace  string         `json:"namespace,omitempty"`
+	Columns    map[string]any `json:"columns,omitempty" jsonschema:"The columns shown for the resource in table, wide and custom columns output, by column name."`
+	Object     map[string]any `json:"object,omitempty" jsonschema:"The full resource, in json and yaml output."`
+}
+
+func (h *handlers) getK8SResource(ctx context.Context, _ *mcp.CallToolRequest, args *getK8SResourceArgs) (*mcp.CallToolResult, *getK8SResourceOutput, error) {
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
 		return params.ErrorResult(fmt.Errorf("failed to get discovery client: %w", err)), nil, nil
 	}
 
-	gvr, _, isNamespaced, err := ResolveGVR(ctx, discoveryClient, args.ResourceType)
+	gvr, gvk, isNamespaced, err := ResolveGVR(ctx, discoveryClient, args.ResourceType)
 	if err != nil {
 		return params.ErrorResult(err), nil, nil
 	}
@@ -76,14 +95,17 @@ func (h *handlers) getK8SResource(ctx context.Context, _ *mcp.CallToolRequest, a
 	}
 
 	var result string
+	// items holds the objects shown, or the Table the server returned instead.
+	var items []unstructured.Unstructured
 	if args.Name != "" {
 		obj, err := resourceInterface.Get(ctx, args.Name, metav1.GetOptions{})
 		if err != nil {
 			return params.ErrorResult(fmt.Errorf("failed to get resource: %w", err)), nil, nil
 		}
 
+		items = []unstructured.Unstructured{*obj}
 		if args.CustomColumns != "" {
-			result, err = FormatCustomColumns([]unstructured.Unstructured{*obj}, args.CustomColumns)
+			result, err = FormatCustomColumns(items, args.CustomColumns)
 		} else {
 			result, err = h.formatResource(obj, args.OutputFormat)
 		}
@@ -99,11 +121,13 @@ func (h *handlers) getK8SResource(ctx context.Context, _ *mcp.CallToolRequest, a
 			return params.ErrorResult(fmt.Errorf("failed to list resources: %w", err)), nil, nil
 		}
 
+		items = list.Items
 		if args.CustomColumns != "" {
 			result, err = FormatCustomColumns(list.Items, args.CustomColumns)
 		} else if useTable {
 			// In table mode, the list.Object itself is the Table
-			result, err = FormatTable(&unstructured.Unstructured{Object: list.Object})
+			items = []unstructured.Unstructured{{Object: list.Object}}
+			result, err = FormatTable(&items[0])
 		} else {
 			result, err = h.formatResourceList(list, args.OutputFormat)
 		}
@@ -112,11 +136,94 @@ func (h *handlers) getK8SResource(ctx context.Context, _ *mcp.CallToolRequest, a
 		}
 	}
 
+	out, err := resourcesOutput(items, gvk, args.CustomColumns)
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: result},
 		},
-	}, nil, nil
+	}, out, nil
+}
+
+// resourcesOutput returns the structured output for items, the objects of
+// kind gvk shown by get_k8s_resource or a Table the server returned in their
+// place. With custom columns, the column values are returned instead of the
+// objects.
+func resourcesOutput(items []unstructured.Unstructured, gvk schema.GroupVersionKind, customColumns string) (*getK8SResourceOutput, error) {
+	var columns []customColumn
+	if customColumns != "" {
+		var err error
+		if columns, err = parseCustomColumns(customColumns); err != nil {
+			return nil, err
+		}
+	}
+
+	out := &getK8SResourceOutput{Resources: make([]k8sResourceOutput, 0, len(items))}
+	for i := range items {
+		item := &items[i]
+		if item.GetKind() == "Table" && columns == nil {
+			rows, err := tableRowsOutput(item, gvk)
+			if err != nil {
+				return nil, err
+			}
+			out.Resources = append(out.Resources, rows...)
+			continue
+		}
+
+		r := k8sResourceOutput{
+			APIVersion: item.GetAPIVersion(),
+			Kind:       item.GetKind(),
+			Name:       item.GetName(),
+			Namespace:  item.GetNamespace(),
+		}
+		if r.Kind == "" {
+			r.APIVersion, r.Kind = gvk.ToAPIVersionAndKind()
+		}
+		if columns != nil {
+			r.Columns = make(map[string]any, len(columns))
+			for _, col := range columns {
+				r.Columns[col.header] = col.value(item.Object)
+			}
+		} else {
+			r.Object = item.Object
+		}
+		out.Resources = append(out.Resources, r)
+	}
+	return out, nil
+}
+
+// tableRowsOutput returns the structured output for the rows of a Table of
+// objects of kind gvk. Each row carries the object's metadata.
+func tableRowsOutput(obj *unstructured.Unstructured, gvk schema.GroupVersionKind) ([]k8sResourceOutput, error) {
+	table, err := toTable(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	rows := make([]k8sResourceOutput, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		r := k8sResourceOutput{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Columns:    make(map[string]any, len(row.Cells)),
+		}
+		for i, cell := range row.Cells {
+			if i < len(table.ColumnDefinitions) {
+				r.Columns[table.ColumnDefinitions[i].Name] = cell
+			}
+		}
+		var meta metav1.PartialObjectMetadata
+		if len(row.Object.Raw) > 0 && json.Unmarshal(row.Object.Raw, &meta) == nil {
+			r.Name = meta.Name
+			r.Namespace = meta.Namespace
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
 }
 
 func (h *handlers) formatResource(obj *unstructured.Unstructured, format string) (string, error) {
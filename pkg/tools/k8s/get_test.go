@@ -16,6 +16,7 @@ package k8s
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -24,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
@@ -60,8 +62,8 @@ func TestGetK8SResourceArgs_Fields(t *testing.T) {
 	if args.OutputFormat != "yaml" {
 		t.Errorf("OutputFormat = %s, want yaml", args.OutputFormat)
 	}
-	if args.ClusterPath() != "projects/p/locations/l/clusters/c" {
-		t.Errorf("ClusterPath = %s, want projects/p/locations/l/clusters/c", args.ClusterPath())
+	if got, err := args.ClusterPath(); err != nil || got != "projects/p/locations/l/clusters/c" {
+		t.Errorf("ClusterPath() = %s, %v, want projects/p/locations/l/clusters/c", got, err)
 	}
 }
 
@@ -121,7 +123,7 @@ func TestGetK8SResource(t *testing.T) {
 	args.Location = "l"
 	args.ClusterName = "c"
 
-	result, _, err := h.getK8SResource(ctx, &mcp.CallToolRequest{}, args)
+	result, out, err := h.getK8SResource(ctx, &mcp.CallToolRequest{}, args)
 	if err != nil {
 		t.Fatalf("getK8SResource failed: %v", err)
 	}
@@ -130,6 +132,13 @@ func TestGetK8SResource(t *testing.T) {
 		t.Fatalf("getK8SResource returned error result: %v", result.Content[0])
 	}
 
+	if len(out.Resources) != 1 {
+		t.Fatalf("len(out.Resources) = %d, want 1", len(out.Resources))
+	}
+	if got := out.Resources[0]; got.Kind != "Pod" || got.Name != "my-pod" || got.Namespace != "default" || got.Object == nil {
+		t.Errorf("out.Resources[0] = %+v, want Pod default/my-pod with its object", got)
+	}
+
 	if len(result.Content) != 1 {
 		t.Fatalf("len(result.Content) = %d, want 1", len(result.Content))
 	}
@@ -144,3 +153,69 @@ func TestGetK8SResource(t *testing.T) {
 		t.Errorf("output does not contain 'my-pod'")
 	}
 }
+
+func TestResourcesOutput(t *testing.T) {
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	pod := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "my-pod", "namespace": "default"},
+		"status":     map[string]interface{}{"phase": "Running"},
+	}}
+	table := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "meta.k8s.io/v1",
+		"kind":       "Table",
+		"columnDefinitions": []interface{}{
+			map[string]interface{}{"name": "Name", "type": "string"},
+			map[string]interface{}{"name": "Status", "type": "string"},
+		},
+		"rows": []interface{}{
+			map[string]interface{}{
+				"cells": []interface{}{"my-pod", "Running"},
+				"object": map[string]interface{}{
+					"kind":       "PartialObjectMetadata",
+					"apiVersion": "meta.k8s.io/v1",
+					"metadata":   map[string]interface{}{"name": "my-pod", "namespace": "default"},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name          string
+		items         []unstructured.Unstructured
+		customColumns string
+		want          k8sResourceOutput
+	}{
+		{
+			name:  "object",
+			items: []unstructured.Unstructured{pod},
+			want:  k8sResourceOutput{APIVersion: "v1", Kind: "Pod", Name: "my-pod", Namespace: "default", Object: pod.Object},
+		},
+		{
+			name:          "custom columns",
+			items:         []unstructured.Unstructured{pod},
+			customColumns: "PHASE:.status.phase",
+			want:          k8sResourceOutput{APIVersion: "v1", Kind: "Pod", Name: "my-pod", Namespace: "default", Columns: map[string]any{"PHASE": "Running"}},
+		},
+		{
+			name:  "table",
+			items: []unstructured.Unstructured{table},
+			want:  k8sResourceOutput{APIVersion: "v1", Kind: "Pod", Name: "my-pod", Namespace: "default", Columns: map[string]any{"Name": "my-pod", "Status": "Running"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := resourcesOutput(tc.items, gvk, tc.customColumns)
+			if err != nil {
+				t.Fatalf("resourcesOutput() failed: %v", err)
+			}
+			if len(out.Resources) != 1 {
+				t.Fatalf("len(out.Resources) = %d, want 1", len(out.Resources))
+			}
+			if got := out.Resources[0]; !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("resourcesOutput() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
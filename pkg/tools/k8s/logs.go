@@ -28,7 +28,7 @@ import (
 )
 
 type getK8SLogsArgs struct {
-	params.Cluster
+	params.KubeCluster
 	Name          string `json:"name" jsonschema:"Required. The name of the pod to retrieve logs from. Only 'pod' resource type is supported in this version."`
 	Namespace     string `json:"namespace,omitempty" jsonschema:"Optional. The namespace of the resource. If not specified, \"default\" is used."`
 	AllContainers bool   `json:"allContainers,omitempty" jsonschema:"Optional. If true, retrieve logs from all containers in the pod."`
@@ -40,7 +40,10 @@ type getK8SLogsArgs struct {
 }
 
 func (h *handlers) getK8SLogs(ctx context.Context, _ *mcp.CallToolRequest, args *getK8SLogsArgs) (*mcp.CallToolResult, any, error) {
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	client, err := h.provider.KubernetesClient(ctx, clusterPath)
 	if err != nil {
@@ -27,7 +27,7 @@ import (
 )
 
 type patchK8SResourceArgs struct {
-	params.Cluster
+	params.KubeCluster
 	ResourceType string `json:"resourceType" jsonschema:"Required. The type of resource to patch. e.g. \"pods\", \"deployments\", \"services\"."`
 	Name         string `json:"name" jsonschema:"Required. The name of the resource to patch."`
 	Namespace    string `json:"namespace,omitempty" jsonschema:"Optional. The namespace of the resource. If not specified, \"default\" is used."`
@@ -39,16 +39,10 @@ func (h *handlers) patchK8SResource(ctx context.Context, _ *mcp.CallToolRequest,
 	if args == nil {
 		return params.ErrorResult(fmt.Errorf("args cannot be nil")), nil, nil
 	}
-	if args.ProjectID == "" {
-		return params.ErrorResult(fmt.Errorf("projectId is required")), nil, nil
-	}
-	if args.Location == "" {
-		return params.ErrorResult(fmt.Errorf("location is required")), nil, nil
-	}
-	if args.ClusterName == "" {
-		return params.ErrorResult(fmt.Errorf("clusterName is required")), nil, nil
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
 	}
-	clusterPath := args.ClusterPath()
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// resourceScheme prefixes the URI of a Kubernetes object exposed as an MCP
+// resource.
+const resourceScheme = "k8s://"
+
+const (
+	namespacedObjectURITemplate    = resourceScheme + "{project}/{location}/{cluster}/{namespace}/{resource}/{name}"
+	clusterScopedObjectURITemplate = resourceScheme + "{project}/{location}/{cluster}/{resource}/{name}"
+
+	resourceMIMEType = "application/json"
+
+	// readObjectTool is the tool that reads the same objects as the resource
+	// templates.
+	readObjectTool = "get_k8s_resource"
+)
+
+// installResources registers Kubernetes objects as resource templates, if
+// get_k8s_resource, which reads the same data, is enabled.
+func installResources(s *mcp.Server, h *handlers) {
+	registry.AddResourceTemplate(s, h.c, readObjectTool, &mcp.ResourceTemplate{
+		Name:        "k8s_object",
+		Title:       "Kubernetes object",
+		Description: "A namespaced Kubernetes object in a GKE cluster, such as k8s://my-project/us-central1/my-cluster/default/deployments/my-app. Subscribe to be notified when it changes.",
+		URITemplate: namespacedObjectURITemplate,
+		MIMEType:    resourceMIMEType,
+	}, h.readObjectResource)
+
+	registry.AddResourceTemplate(s, h.c, readObjectTool, &mcp.ResourceTemplate{
+		Name:        "k8s_cluster_object",
+		Title:       "Kubernetes cluster-scoped object",
+		Description: "A cluster-scoped Kubernetes object in a GKE cluster, such as k8s://my-project/us-central1/my-cluster/nodes/my-node. Subscribe to be notified when it changes.",
+		URITemplate: clusterScopedObjectURITemplate,
+		MIMEType:    resourceMIMEType,
+	}, h.readObjectResource)
+}
+
+// objectRef identifies the Kubernetes object named by a k8s:// URI.
+type objectRef struct {
+	clusterPath string
+	namespace   string // empty for cluster-scoped objects
+	resource    string
+	name        string
+}
+
+// parseObjectURI returns the object named by uri, in the form of either
+// resource template.
+func parseObjectURI(uri string) (objectRef, error) {
+	path, ok := strings.CutPrefix(uri, resourceScheme)
+	if !ok {
+		return objectRef{}, fmt.Errorf("%q is not a %s URI", uri, resourceScheme)
+	}
+	segments := strings.Split(path, "/")
+	if len(segments) != 5 && len(segments) != 6 {
+		return objectRef{}, fmt.Errorf("%q does not match %s or %s", uri, namespacedObjectURITemplate, clusterScopedObjectURITemplate)
+	}
+	for i, s := range segments {
+		unescaped, err := url.PathUnescape(s)
+		if err != nil || unescaped == "" {
+			return objectRef{}, fmt.Errorf("%q has an invalid or empty segment %q", uri, s)
+		}
+		segments[i] = unescaped
+	}
+
+	ref := objectRef{
+		clusterPath: fmt.Sprintf("projects/%s/locations/%s/clusters/%s", segments[0], segments[1], segments[2]),
+		resource:    segments[len(segments)-2],
+		name:        segments[len(segments)-1],
+	}
+	if len(segments) == 6 {
+		ref.namespace = segments[3]
+	}
+	return ref, nil
+}
+
+// resolveObject returns a dynamic client for the cluster of ref and the
+// resource it names, checking that ref is namespaced if and only if the
+// resource is.
+func resolveObject(ctx context.Context, provider Provider, ref objectRef) (dynamic.Interface, schema.GroupVersionResource, error) {
+	discoveryClient, err := provider.DiscoveryClient(ctx, ref.clusterPath)
+	if err != nil {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("failed to get discovery client: %w", err)
+	}
+	gvr, _, isNamespaced, err := ResolveGVR(ctx, discoveryClient, ref.resource)
+	if err != nil {
+		return nil, schema.GroupVersionResource{}, err
+	}
+	if isNamespaced && ref.namespace == "" {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("%s is namespaced; use %s", ref.resource, namespacedObjectURITemplate)
+	}
+	if !isNamespaced && ref.namespace != "" {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("%s is cluster-scoped; use %s", ref.resource, clusterScopedObjectURITemplate)
+	}
+
+	dynamicClient, err := provider.DynamicClient(ctx, ref.clusterPath)
+	if err != nil {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+	return dynamicClient, gvr, nil
+}
+
+func (h *handlers) readObjectResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	ref, err := parseObjectURI(uri)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	dynamicClient, gvr, err := resolveObject(ctx, h.provider, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := dynamicClient.Resource(gvr).Namespace(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", ref.resource, ref.name, err)
+	}
+	data, err := json.MarshalIndent(obj.Object, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", ref.resource, ref.name, err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: resourceMIMEType,
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseObjectURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    objectRef
+		wantErr bool
+	}{
+		{
+			uri:  "k8s://p/us-central1/c/default/pods/my-pod",
+			want: objectRef{clusterPath: "projects/p/locations/us-central1/clusters/c", namespace: "default", resource: "pods", name: "my-pod"},
+		},
+		{
+			uri:  "k8s://p/us-central1/c/clusterroles/system%3Aadmin",
+			want: objectRef{clusterPath: "projects/p/locations/us-central1/clusters/c", resource: "clusterroles", name: "system:admin"},
+		},
+		{uri: "gke://p/us-central1/c/default/pods/my-pod", wantErr: true},
+		{uri: "k8s://p/us-central1/c/pods", wantErr: true},
+		{uri: "k8s://p/us-central1/c//pods/my-pod", wantErr: true},
+		{uri: "k8s://p/us-central1/c/default/pods/my-pod/log", wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := parseObjectURI(tc.uri)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseObjectURI(%q) error = %v, wantErr %t", tc.uri, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseObjectURI(%q) = %+v, want %+v", tc.uri, got, tc.want)
+		}
+	}
+}
+
+func newTestPod(resourceVersion string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":            "my-pod",
+				"namespace":       "default",
+				"resourceVersion": resourceVersion,
+			},
+			"status": map[string]interface{}{
+				"phase": "Pending",
+			},
+		},
+	}
+}
+
+// newResourcesProvider returns a provider for a cluster with pods and nodes
+// that contains objs.
+func newResourcesProvider(objs ...runtime.Object) (*mockClientProvider, *dynamicfake.FakeDynamicClient) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objs...)
+	fakeDiscovery := fake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	fakeDiscovery.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod"},
+				{Name: "nodes", Namespaced: false, Kind: "Node"},
+			},
+		},
+	}
+	return &mockClientProvider{dynamicClient: dynamicClient, discoveryClient: fakeDiscovery}, dynamicClient
+}
+
+// connectResourceServer serves the Kubernetes resources of provider, with
+// subscriptions if subs is not nil, and returns a connected client session.
+func connectResourceServer(t *testing.T, provider Provider, subs *Subscriptions, opts *mcp.ClientOptions) (*mcp.Server, *mcp.ClientSession) {
+	t.Helper()
+	ctx := context.Background()
+	serverOpts := &mcp.ServerOptions{}
+	if subs != nil {
+		serverOpts.SubscribeHandler = subs.Subscribe
+		serverOpts.UnsubscribeHandler = subs.Unsubscribe
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, serverOpts)
+	installResources(server, &handlers{c: &config.Config{}, provider: provider})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, opts).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return server, session
+}
+
+func TestReadObjectResource(t *testing.T) {
+	provider, _ := newResourcesProvider(newTestPod("1"))
+	_, session := connectResourceServer(t, provider, nil, nil)
+	ctx := context.Background()
+
+	const uri = "k8s://p/us-central1/c/default/pods/my-pod"
+	res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		t.Fatalf("ReadResource() failed: %v", err)
+	}
+	if len(res.Contents) != 1 {
+		t.Fatalf("len(res.Contents) = %d, want 1", len(res.Contents))
+	}
+	if got := res.Contents[0]; got.MIMEType != "application/json" || !strings.Contains(got.Text, `"phase": "Pending"`) {
+		t.Errorf("ReadResource() = %+v, want the pod as JSON", got)
+	}
+
+	_, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s://p/us-central1/c/default/pods/missing"})
+	var rpcErr *jsonrpc.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != mcp.CodeResourceNotFound {
+		t.Errorf("ReadResource() of a missing pod = %v, want resource not found", err)
+	}
+
+	_, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s://p/us-central1/c/pods/my-pod"})
+	if err == nil || !strings.Contains(err.Error(), "namespaced") {
+		t.Errorf("ReadResource() of a pod without a namespace = %v, want namespaced error", err)
+	}
+}
@@ -25,7 +25,7 @@ import (
 )
 
 type getK8SRolloutStatusArgs struct {
-	params.Cluster
+	params.KubeCluster
 	ResourceType string `json:"resourceType" jsonschema:"Required. The type of resource to check. e.g. \"deployment\", \"daemonset\", \"statefulset\"."`
 	Name         string `json:"name" jsonschema:"Required. The name of the resource to check."`
 	Namespace    string `json:"namespace" jsonschema:"Required. The namespace of the resource."`
@@ -38,7 +38,10 @@ func (h *handlers) getK8SRolloutStatus(ctx context.Context, _ *mcp.CallToolReque
 	if args.Namespace == "" {
 		return params.ErrorResult(fmt.Errorf("namespace is required")), nil, nil
 	}
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
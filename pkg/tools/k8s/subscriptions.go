@@ -0,0 +1,240 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// subscribeSyncTimeout bounds how long Subscribe waits for the initial list of
+// a watched object.
+var subscribeSyncTimeout = 30 * time.Second
+
+// Subscriptions watches the Kubernetes objects that clients subscribe to as
+// k8s:// resources, and calls notify with the URI of an object whenever it is
+// created, changed or deleted. Each object is watched once, for as long as
+// any session is subscribed to it.
+type Subscriptions struct {
+	provider Provider
+	notify   func(ctx context.Context, uri string)
+	// unavailable is returned by Subscribe if objects cannot be watched.
+	unavailable error
+
+	mu       sync.Mutex
+	watches  map[string]*objectWatch
+	sessions map[*mcp.ServerSession]bool
+}
+
+// objectWatch is the watch of a subscribed object.
+type objectWatch struct {
+	cancel      context.CancelFunc
+	subscribers map[*mcp.ServerSession]bool
+	// ready is closed once the initial list of the object has completed, or
+	// failed with err.
+	ready chan struct{}
+	err   error
+}
+
+// NewSubscriptions returns Subscriptions that watch objects using clients
+// configured by c and report their changes to notify, typically
+// mcp.Server.ResourceUpdated.
+func NewSubscriptions(c *config.Config, notify func(ctx context.Context, uri string)) *Subscriptions {
+	s := newSubscriptions(NewClientProvider(c), notify)
+	switch {
+	case c.CallerCredentials():
+		// Watches outlive the request, and so the caller's token.
+		s.unavailable = errors.New("resource subscriptions are not supported with caller credentials")
+	case !registry.ResourceEnabled(c, readObjectTool):
+		s.unavailable = fmt.Errorf("resource subscriptions are disabled along with %s", readObjectTool)
+	}
+	return s
+}
+
+func newSubscriptions(provider Provider, notify func(ctx context.Context, uri string)) *Subscriptions {
+	return &Subscriptions{
+		provider: provider,
+		notify:   notify,
+		watches:  make(map[string]*objectWatch),
+		sessions: make(map[*mcp.ServerSession]bool),
+	}
+}
+
+// Subscribe starts watching the object named by the k8s:// URI of req, unless
+// it is already watched for another subscriber. Either way the object is read
+// first, so that only sessions that can read it are subscribed, and Subscribe
+// returns once the watch has listed the object. It is an
+// mcp.ServerOptions.SubscribeHandler.
+func (s *Subscriptions) Subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	if s.unavailable != nil {
+		return s.unavailable
+	}
+	uri := req.Params.URI
+	ref, err := parseObjectURI(uri)
+	if err != nil {
+		return err
+	}
+	dynamicClient, gvr, err := resolveObject(ctx, s.provider, ref)
+	if err != nil {
+		return err
+	}
+	// Objects that do not exist yet can be subscribed to, to follow their
+	// creation.
+	if _, err := dynamicClient.Resource(gvr).Namespace(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to read %s %s: %w", ref.resource, ref.name, err)
+	}
+
+	s.mu.Lock()
+	if w, ok := s.watches[uri]; ok {
+		w.subscribers[req.Session] = true
+		s.trackSessionLocked(req.Session)
+		s.mu.Unlock()
+		return s.waitForWatch(ctx, uri, w, req.Session)
+	}
+	watchCtx, cancel := context.WithCancel(context.Background())
+	w := &objectWatch{
+		cancel:      cancel,
+		subscribers: map[*mcp.ServerSession]bool{req.Session: true},
+		ready:       make(chan struct{}),
+	}
+	s.watches[uri] = w
+	s.trackSessionLocked(req.Session)
+	s.mu.Unlock()
+
+	informer := dynamicinformer.NewFilteredDynamicInformer(dynamicClient, gvr, ref.namespace, 0, cache.Indexers{}, func(opts *metav1.ListOptions) {
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", ref.name).String()
+	}).Informer()
+	changed := func() { s.notify(watchCtx, uri) }
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(_ any, isInInitialList bool) {
+			if !isInInitialList {
+				changed()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			// Relists after the watch expires report unchanged objects too.
+			if resourceVersion(oldObj) != resourceVersion(newObj) {
+				changed()
+			}
+		},
+		DeleteFunc: func(any) { changed() },
+	}); err != nil {
+		s.failWatch(uri, w, err)
+		return err
+	}
+	go informer.RunWithContext(watchCtx)
+
+	// Wait for the initial list, so that any later change is reported and a
+	// watch the user is not allowed to make fails the subscription.
+	syncCtx, cancelSync := context.WithTimeout(ctx, subscribeSyncTimeout)
+	defer cancelSync()
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		err := fmt.Errorf("timed out listing %s %s; check that the cluster is reachable and that you can watch %s", ref.resource, ref.name, ref.resource)
+		s.failWatch(uri, w, err)
+		return err
+	}
+	close(w.ready)
+	return nil
+}
+
+// waitForWatch waits until the watch w of uri, which session joined while it
+// may still be listing the object, has started, and returns the error it
+// failed with.
+func (s *Subscriptions) waitForWatch(ctx context.Context, uri string, w *objectWatch, session *mcp.ServerSession) error {
+	select {
+	case <-w.ready:
+		return w.err
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.watches[uri] == w {
+			s.removeSubscriberLocked(uri, session)
+		}
+		return ctx.Err()
+	}
+}
+
+// failWatch stops the watch w of uri, which could not be started, and fails
+// the subscriptions of every session that joined it in the meantime with err.
+func (s *Subscriptions) failWatch(uri string, w *objectWatch, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watches[uri] == w {
+		delete(s.watches, uri)
+	}
+	w.cancel()
+	w.err = err
+	close(w.ready)
+}
+
+// Unsubscribe stops watching the object named by the URI of req once no other
+// session is subscribed to it. It is an mcp.ServerOptions.UnsubscribeHandler.
+func (s *Subscriptions) Unsubscribe(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeSubscriberLocked(req.Params.URI, req.Session)
+	return nil
+}
+
+func (s *Subscriptions) removeSubscriberLocked(uri string, session *mcp.ServerSession) {
+	w, ok := s.watches[uri]
+	if !ok {
+		return
+	}
+	delete(w.subscribers, session)
+	if len(w.subscribers) == 0 {
+		w.cancel()
+		delete(s.watches, uri)
+	}
+}
+
+// trackSessionLocked removes the subscriptions of session once it ends, as
+// clients that disconnect do not unsubscribe.
+func (s *Subscriptions) trackSessionLocked(session *mcp.ServerSession) {
+	if session == nil || s.sessions[session] {
+		return
+	}
+	s.sessions[session] = true
+	go func() {
+		_ = session.Wait()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.sessions, session)
+		for uri := range s.watches {
+			s.removeSubscriberLocked(uri, session)
+		}
+	}()
+}
+
+func resourceVersion(obj any) string {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return m.GetResourceVersion()
+}
@@ -0,0 +1,257 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+// watched reports the number of objects being watched.
+func (s *Subscriptions) watched() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.watches)
+}
+
+// waitForWatched waits until subs watches want objects. Clients subscribe in
+// the background, so the watch may start after Subscribe returns.
+func waitForWatched(t *testing.T, subs *Subscriptions, want int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for subs.watched() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("watched() = %d, want %d", subs.watched(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// updateUntilNotified updates the test pod until updates reports a change of
+// want, as the subscription only becomes active some time after the watch
+// starts.
+func updateUntilNotified(t *testing.T, pods dynamic.ResourceInterface, updates <-chan string, want string) {
+	t.Helper()
+	deadline := time.After(10 * time.Second)
+	for rv := 2; ; rv++ {
+		if _, err := pods.Update(context.Background(), newTestPod(strconv.Itoa(rv)), metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update pod: %v", err)
+		}
+		select {
+		case uri := <-updates:
+			if uri != want {
+				t.Errorf("resources/updated for %s, want %s", uri, want)
+			}
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("no resources/updated notification for %s", want)
+		}
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	provider, dynamicClient := newResourcesProvider(newTestPod("1"))
+	var server *mcp.Server
+	subs := newSubscriptions(provider, func(ctx context.Context, uri string) {
+		_ = server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+	})
+	updates := make(chan string, 10)
+	server, session := connectResourceServer(t, provider, subs, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updates <- req.Params.URI
+		},
+	})
+	ctx := context.Background()
+
+	const uri = "k8s://p/us-central1/c/default/pods/my-pod"
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+	waitForWatched(t, subs, 1)
+
+	pods := dynamicClient.Resource(podsGVR).Namespace("default")
+	updateUntilNotified(t, pods, updates, uri)
+
+	// Drain notifications of updates made before the first one arrived.
+	for drained := false; !drained; {
+		select {
+		case <-updates:
+		case <-time.After(300 * time.Millisecond):
+			drained = true
+		}
+	}
+	if err := pods.Delete(ctx, "my-pod", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete pod: %v", err)
+	}
+	select {
+	case <-updates:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no resources/updated notification after deleting the pod")
+	}
+
+	if err := session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Unsubscribe() failed: %v", err)
+	}
+	waitForWatched(t, subs, 0)
+}
+
+func TestSubscriptionsEndWithSession(t *testing.T) {
+	provider, _ := newResourcesProvider(newTestPod("1"))
+	subs := newSubscriptions(provider, func(context.Context, string) {})
+	_, session := connectResourceServer(t, provider, subs, nil)
+
+	if err := session.Subscribe(context.Background(), &mcp.SubscribeParams{URI: "k8s://p/us-central1/c/default/pods/my-pod"}); err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+	waitForWatched(t, subs, 1)
+	_ = session.Close()
+	waitForWatched(t, subs, 0)
+}
+
+func TestSubscribeInvalidURI(t *testing.T) {
+	provider, _ := newResourcesProvider()
+	subs := newSubscriptions(provider, func(context.Context, string) {})
+
+	for _, uri := range []string{
+		"gke://projects/p/locations/l/clusters/c",
+		"k8s://p/us-central1/c/widgets/my-widget",
+		"k8s://p/us-central1/c/pods/my-pod",
+	} {
+		if err := subs.Subscribe(context.Background(), &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: uri}}); err == nil {
+			t.Errorf("Subscribe(%s) succeeded, want error", uri)
+		}
+	}
+	if got := subs.watched(); got != 0 {
+		t.Errorf("watched() = %d, want 0", got)
+	}
+}
+
+func TestSubscribeChecksAccess(t *testing.T) {
+	provider, dynamicClient := newResourcesProvider(newTestPod("1"))
+	// The reactor is added before the watch starts, as the fake client does not
+	// allow adding reactors concurrently with its use.
+	var forbidden atomic.Bool
+	dynamicClient.PrependReactor("get", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if !forbidden.Load() {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(podsGVR.GroupResource(), "my-pod", errors.New("denied"))
+	})
+	subs := newSubscriptions(provider, func(context.Context, string) {})
+	server, session := connectResourceServer(t, provider, subs, nil)
+	ctx := context.Background()
+	const uri = "k8s://p/us-central1/c/default/pods/my-pod"
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+	waitForWatched(t, subs, 1)
+
+	// A session that cannot read the object does not join its watch.
+	forbidden.Store(true)
+	_, serverTransport := mcp.NewInMemoryTransports()
+	other, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = other.Close() })
+	err = subs.Subscribe(ctx, &mcp.SubscribeRequest{Session: other, Params: &mcp.SubscribeParams{URI: uri}})
+	if !apierrors.IsForbidden(err) {
+		t.Errorf("Subscribe() without access = %v, want forbidden", err)
+	}
+
+	subs.mu.Lock()
+	defer subs.mu.Unlock()
+	if w := subs.watches[uri]; w == nil || len(w.subscribers) != 1 {
+		t.Errorf("watch = %+v, want one subscriber", w)
+	}
+}
+
+func TestSubscribeFailedSync(t *testing.T) {
+	orig := subscribeSyncTimeout
+	subscribeSyncTimeout = time.Second
+	t.Cleanup(func() { subscribeSyncTimeout = orig })
+
+	provider, dynamicClient := newResourcesProvider(newTestPod("1"))
+	// Sessions can read the object but not list it, so the watch never syncs.
+	dynamicClient.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(podsGVR.GroupResource(), "", errors.New("denied"))
+	})
+	subs := newSubscriptions(provider, func(context.Context, string) {})
+	server, _ := connectResourceServer(t, provider, subs, nil)
+	ctx := context.Background()
+	const uri = "k8s://p/us-central1/c/default/pods/my-pod"
+
+	var sessions []*mcp.ServerSession
+	for range 2 {
+		_, serverTransport := mcp.NewInMemoryTransports()
+		ss, err := server.Connect(ctx, serverTransport, nil)
+		if err != nil {
+			t.Fatalf("server.Connect() failed: %v", err)
+		}
+		t.Cleanup(func() { _ = ss.Close() })
+		sessions = append(sessions, ss)
+	}
+
+	first := make(chan error, 1)
+	go func() {
+		first <- subs.Subscribe(ctx, &mcp.SubscribeRequest{Session: sessions[0], Params: &mcp.SubscribeParams{URI: uri}})
+	}()
+	waitForWatched(t, subs, 1)
+	// The second session joins the watch while it is still listing the object.
+	if err := subs.Subscribe(ctx, &mcp.SubscribeRequest{Session: sessions[1], Params: &mcp.SubscribeParams{URI: uri}}); err == nil {
+		t.Error("Subscribe() joining a watch that failed to sync succeeded, want error")
+	}
+	if err := <-first; err == nil {
+		t.Error("Subscribe() starting a watch that failed to sync succeeded, want error")
+	}
+	if got := subs.watched(); got != 0 {
+		t.Errorf("watched() = %d after the watch failed, want 0", got)
+	}
+}
+
+func TestSubscriptionsUnavailable(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *config.Config
+	}{
+		{"caller credentials", config.NewTestConfig("p", "l", "", "", config.WithCallerCredentials())},
+		{"get_k8s_resource disabled", config.NewTestConfig("p", "l", "", "", config.WithToolFilter(config.ToolFilter{Disable: []string{"get_k8s_resource"}}))},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			subs := NewSubscriptions(tc.c, func(context.Context, string) {})
+			req := &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: "k8s://p/us-central1/c/default/pods/my-pod"}}
+			if err := subs.Subscribe(context.Background(), req); err == nil {
+				t.Error("Subscribe() succeeded, want error")
+			}
+		})
+	}
+}
@@ -24,17 +24,25 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-// FormatTable formats a Kubernetes Table object (stored in Unstructured) into a human-readable string.
-func FormatTable(obj *unstructured.Unstructured) (string, error) {
-	// Convert Unstructured to metav1.Table
+// toTable converts a Kubernetes Table object stored in Unstructured to a metav1.Table.
+func toTable(obj *unstructured.Unstructured) (*metav1.Table, error) {
 	data, err := obj.MarshalJSON()
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal unstructured object: %w", err)
+		return nil, fmt.Errorf("failed to marshal unstructured object: %w", err)
 	}
 
 	var table metav1.Table
 	if err := json.Unmarshal(data, &table); err != nil {
-		return "", fmt.Errorf("failed to unmarshal Table: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal Table: %w", err)
+	}
+	return &table, nil
+}
+
+// FormatTable formats a Kubernetes Table object (stored in Unstructured) into a human-readable string.
+func FormatTable(obj *unstructured.Unstructured) (string, error) {
+	table, err := toTable(obj)
+	if err != nil {
+		return "", err
 	}
 
 	buf := new(bytes.Buffer)
@@ -28,11 +28,11 @@ type handlers struct {
 	provider Provider
 }
 
-// Install registers Kubernetes-related tools with the MCP server.
+// Install registers Kubernetes-related tools and resources with the MCP server.
 func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 	h := &handlers{
 		c:        c,
-		provider: NewClientProvider(),
+		provider: NewClientProvider(c),
 	}
 
 	registry.RegisterTool(s, h.c, &mcp.Tool{
@@ -43,7 +43,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.getK8SResource)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "list_k8s_events",
 		Description: "Retrieves events from a Kubernetes cluster. This is similar to running `kubectl events`.",
 		Annotations: &mcp.ToolAnnotations{
@@ -51,7 +51,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.listK8SEvents)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "list_k8s_api_resources",
 		Description: "Retrieves the available API groups and resources from a Kubernetes cluster. This is similar to running `kubectl api-resources`.",
 		Annotations: &mcp.ToolAnnotations{
@@ -59,7 +59,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.listK8SAPIResources)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_k8s_version",
 		Description: "Retrieves the Kubernetes server version for a given cluster. This is similar to running kubectl version.",
 		Annotations: &mcp.ToolAnnotations{
@@ -67,7 +67,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.getK8SVersion)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_k8s_cluster_info",
 		Description: "Gets cluster endpoint information. This is similar to running `kubectl cluster-info`.",
 		Annotations: &mcp.ToolAnnotations{
@@ -75,12 +75,12 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.getK8SClusterInfo)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "apply_k8s_manifest",
 		Description: "Applies a Kubernetes manifest to a cluster using server-side apply. This is similar to running `kubectl apply --server-side`.",
 	}, h.applyK8SManifest)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_k8s_logs",
 		Description: "Gets logs from a Kubernetes container in a pod. This is similar to running `kubectl logs`.",
 		Annotations: &mcp.ToolAnnotations{
@@ -88,17 +88,17 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.getK8SLogs)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "delete_k8s_resource",
 		Description: "Deletes a Kubernetes resource from a cluster. This is similar to running `kubectl delete`.",
 	}, h.deleteK8SResource)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "patch_k8s_resource",
 		Description: "Patches a Kubernetes resource. This is similar to running `kubectl patch`.",
 	}, h.patchK8SResource)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_k8s_rollout_status",
 		Description: "Checks the current rollout status of a Kubernetes resource. This is similar to running `kubectl rollout status`.",
 		Annotations: &mcp.ToolAnnotations{
@@ -106,7 +106,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.getK8SRolloutStatus)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "check_k8s_auth",
 		Description: "Checks whether an action is allowed on a Kubernetes resource. This is similar to running `kubectl auth can-i`.",
 		Annotations: &mcp.ToolAnnotations{
@@ -114,7 +114,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.checkK8SAuth)
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "describe_k8s_resource",
 		Description: "Shows the details of a specific Kubernetes resource. This is similar to running `kubectl describe`.",
 		Annotations: &mcp.ToolAnnotations{
@@ -122,5 +122,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		},
 	}, h.describeK8SResource)
 
+	installResources(s, h)
+
 	return nil
 }
@@ -23,14 +23,17 @@ import (
 )
 
 type getK8SVersionArgs struct {
-	params.Cluster
+	params.KubeCluster
 }
 
 func (h *handlers) getK8SVersion(ctx context.Context, _ *mcp.CallToolRequest, args *getK8SVersionArgs) (*mcp.CallToolResult, any, error) {
 	if args == nil {
 		return params.ErrorResult(fmt.Errorf("args cannot be nil")), nil, nil
 	}
-	clusterPath := args.ClusterPath()
+	clusterPath, err := args.ClusterPath()
+	if err != nil {
+		return params.ErrorResult(err), nil, nil
+	}
 
 	discoveryClient, err := h.provider.DiscoveryClient(ctx, clusterPath)
 	if err != nil {
@@ -19,12 +19,13 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -38,8 +39,8 @@ type getK8sChangelogArgs struct {
 }
 
 // Install registers Kubernetes changelog tools with the MCP server.
-func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
-	mcp.AddTool(s, &mcp.Tool{
+func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_k8s_changelog",
 		Description: "Get changelog file for a specific kubernetes minor version and keep only changes content. Prefer to use this tool if kubernetes minor version changelog is needed.",
 		Annotations: &mcp.ToolAnnotations{
@@ -51,7 +52,7 @@ func Install(_ context.Context, s *mcp.Server, _ *config.Config) error {
 	return nil
 }
 
-func getK8sChangelog(_ context.Context, _ *mcp.CallToolRequest, args *getK8sChangelogArgs) (*mcp.CallToolResult, any, error) {
+func getK8sChangelog(ctx context.Context, _ *mcp.CallToolRequest, args *getK8sChangelogArgs) (*mcp.CallToolResult, any, error) {
 	version := strings.TrimSpace(args.KubernetesMinorVersion)
 	if !kubernetesMinorVersionRegexp.MatchString(version) {
 		return nil, nil, fmt.Errorf("invalid kubernetes minor version: %s", version)
@@ -61,20 +62,20 @@ func getK8sChangelog(_ context.Context, _ *mcp.CallToolRequest, args *getK8sChan
 	// #nosec G107
 	resp, err := http.Get(changelogURL)
 	if err != nil {
-		log.Printf("Failed to get changelog: %v", err)
+		serverlog.Errorf(ctx, "Failed to get changelog: %v", err)
 		return nil, nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		err := fmt.Errorf("failed to get changelog with status code: %d", resp.StatusCode)
-		log.Printf("Failed to get changelog: %v", err)
+		serverlog.Errorf(ctx, "Failed to get changelog: %v", err)
 		return nil, nil, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("Failed to read changelog response body: %v", err)
+		serverlog.Errorf(ctx, "Failed to read changelog response body: %v", err)
 		return nil, nil, err
 	}
 	changelogFileContent := string(body)
@@ -19,7 +19,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
 	"text/template"
 	"time"
@@ -27,10 +26,10 @@ import (
 	logging "cloud.google.com/go/logging/apiv2"
 	"cloud.google.com/go/logging/apiv2/loggingpb"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/iterator"
-	"google.golang.org/api/option"
 	_ "google.golang.org/genproto/googleapis/cloud/audit" // Import for AuditLog proto so we can convert to JSON.
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -52,6 +51,24 @@ type TimeRange struct {
 	EndTime   time.Time `json:"end_time" jsonschema:"End time for log query (RFC3339 format)"`
 }
 
+// LogQueryResult is the structured output of query_logs.
+type LogQueryResult struct {
+	ProjectID string          `json:"projectId" jsonschema:"The GCP project ID the logs were queried from."`
+	Filter    string          `json:"filter" jsonschema:"The LQL filter used, including the time range."`
+	Entries   []LogQueryEntry `json:"entries" jsonschema:"The log entries, oldest first."`
+	Truncated bool            `json:"truncated,omitempty" jsonschema:"True if more entries matched than the limit."`
+}
+
+// LogQueryEntry is a log entry in the output of query_logs.
+type LogQueryEntry struct {
+	Timestamp string         `json:"timestamp,omitempty"`
+	Severity  string         `json:"severity,omitempty"`
+	LogName   string         `json:"logName,omitempty"`
+	Message   any            `json:"message,omitempty"`
+	Entry     map[string]any `json:"entry,omitempty" jsonschema:"The full log entry, in FULL view."`
+	Text      string         `json:"text,omitempty" jsonschema:"The entry formatted with the format template, if one was given."`
+}
+
 const (
 	defaultLimit = 10
 	maxLimit     = 100
@@ -79,12 +96,12 @@ func newQueryLogsTool(conf *config.Config) *queryLogsTool {
 	}
 }
 
-func (t *queryLogsTool) queryLogs(ctx context.Context, _ *mcp.CallToolRequest, req *LogQueryRequest) (*mcp.CallToolResult, any, error) {
+func (t *queryLogsTool) queryLogs(ctx context.Context, _ *mcp.CallToolRequest, req *LogQueryRequest) (*mcp.CallToolResult, *LogQueryResult, error) {
 	req.setDefaults()
 	if err := req.validate(); err != nil {
 		return nil, nil, err
 	}
-	result, err := t.queryGCPLogs(ctx, req)
+	result, out, err := t.queryGCPLogs(ctx, req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -93,7 +110,7 @@ func (t *queryLogsTool) queryLogs(ctx context.Context, _ *mcp.CallToolRequest, r
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: result},
 		},
-	}, nil, nil
+	}, out, nil
 }
 
 func (r *LogQueryRequest) setDefaults() {
@@ -135,14 +152,14 @@ func (r *LogQueryRequest) validate() error {
 	return nil
 }
 
-func (t *queryLogsTool) queryGCPLogs(ctx context.Context, req *LogQueryRequest) (string, error) {
-	client, err := logging.NewClient(ctx, option.WithUserAgent(t.conf.UserAgent()))
+func (t *queryLogsTool) queryGCPLogs(ctx context.Context, req *LogQueryRequest) (string, *LogQueryResult, error) {
+	client, err := logging.NewClient(ctx, t.conf.GoogleClientOptions()...)
 	if err != nil {
-		return "", fmt.Errorf("failed to create logging client: %v", err)
+		return "", nil, fmt.Errorf("failed to create logging client: %v", err)
 	}
 	defer func() {
 		if err := client.Close(); err != nil {
-			log.Printf("Failed to close logging client: %v\n", err)
+			serverlog.Warningf(ctx, "Failed to close logging client: %v\n", err)
 		}
 	}()
 
@@ -160,7 +177,7 @@ func (t *queryLogsTool) queryGCPLogs(ctx context.Context, req *LogQueryRequest)
 			break
 		}
 		if err != nil {
-			return "", fmt.Errorf("failed to iterate log entries: %v", err)
+			return "", nil, fmt.Errorf("failed to iterate log entries: %v", err)
 		}
 		entries = append(entries, entry)
 		if len(entries) > req.Limit {
@@ -173,13 +190,26 @@ func (t *queryLogsTool) queryGCPLogs(ctx context.Context, req *LogQueryRequest)
 		entries = entries[:req.Limit]
 	}
 
+	return formatLogEntries(req, listLogsReq.Filter, entries, truncated)
+}
+
+// formatLogEntries renders the entries returned for req with filter as text
+// and as structured output.
+func formatLogEntries(req *LogQueryRequest, filter string, entries []*loggingpb.LogEntry, truncated bool) (string, *LogQueryResult, error) {
+	out := &LogQueryResult{
+		ProjectID: req.ProjectID,
+		Filter:    filter,
+		Entries:   make([]LogQueryEntry, 0, len(entries)),
+		Truncated: truncated,
+	}
+
 	allLogLines := strings.Builder{}
 	if len(entries) == 0 {
 		allLogLines.WriteString("No log entries found.")
 	} else {
 		formatter, err := formatterForRequest(req)
 		if err != nil {
-			return "", fmt.Errorf("failed to create formatter: %w", err)
+			return "", nil, fmt.Errorf("failed to create formatter: %w", err)
 		}
 
 		for i, entry := range entries {
@@ -188,18 +218,29 @@ func (t *queryLogsTool) queryGCPLogs(ctx context.Context, req *LogQueryRequest)
 			}
 			logLine, err := formatter.format(entry)
 			if err != nil {
-				return "", fmt.Errorf("failed to format log entry: %w", err)
+				return "", nil, fmt.Errorf("failed to format log entry: %w", err)
 			}
 			allLogLines.WriteString(logLine)
+
+			e := newLogQueryEntry(entry)
+			switch formatter.(type) {
+			case *goTemplateFormatter:
+				e.Text = logLine
+			case *jsonFormatter:
+				if err := json.Unmarshal([]byte(logLine), &e.Entry); err != nil {
+					return "", nil, fmt.Errorf("failed to convert log entry: %w", err)
+				}
+			}
+			out.Entries = append(out.Entries, e)
 		}
 	}
 
-	result := fmt.Sprintf("Project ID: %s\nLQL Query:\n```\n%s\n```\nResult:\n\n%s", req.ProjectID, listLogsReq.Filter, allLogLines.String())
+	result := fmt.Sprintf("Project ID: %s\nLQL Query:\n```\n%s\n```\nResult:\n\n%s", req.ProjectID, filter, allLogLines.String())
 	if truncated {
 		result += fmt.Sprintf("\n\nWarning: Results truncated. The query returned more than the limit of %d log entries. You can use the `limit` parameter to request more entries (up to %d).", req.Limit, maxLimit)
 	}
 
-	return result, nil
+	return result, out, nil
 }
 
 func buildListLogEntriesRequest(req *LogQueryRequest) *loggingpb.ListLogEntriesRequest {
@@ -273,16 +314,19 @@ func (f *jsonFormatter) format(entry *loggingpb.LogEntry) (string, error) {
 	return string(logLine), nil
 }
 
-type compactLogEntry struct {
-	Timestamp string `json:"timestamp,omitempty"`
-	Severity  string `json:"severity,omitempty"`
-	LogName   string `json:"logName,omitempty"`
-	Message   any    `json:"message,omitempty"`
-}
-
 type compactFormatter struct{}
 
 func (f *compactFormatter) format(entry *loggingpb.LogEntry) (string, error) {
+	b, err := json.MarshalIndent(newLogQueryEntry(entry), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not marshal compact log entry to JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+// newLogQueryEntry returns the timestamp, severity, log name and message of
+// entry, as shown in BASIC view.
+func newLogQueryEntry(entry *loggingpb.LogEntry) LogQueryEntry {
 	var ts string
 	if t := entry.GetTimestamp(); t != nil && t.IsValid() {
 		ts = t.AsTime().Format(time.RFC3339)
@@ -291,17 +335,12 @@ func (f *compactFormatter) format(entry *loggingpb.LogEntry) (string, error) {
 	if entry.GetSeverity() != 0 {
 		sev = entry.GetSeverity().String()
 	}
-	compact := compactLogEntry{
+	return LogQueryEntry{
 		Timestamp: ts,
 		Severity:  sev,
 		LogName:   entry.GetLogName(),
 		Message:   extractMessage(entry),
 	}
-	b, err := json.MarshalIndent(compact, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("could not marshal compact log entry to JSON: %w", err)
-	}
-	return string(b), nil
 }
 
 func extractMessage(entry *loggingpb.LogEntry) any {
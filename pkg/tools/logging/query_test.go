@@ -310,3 +310,83 @@ func TestFormatter(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatLogEntries(t *testing.T) {
+	entry := &loggingpb.LogEntry{
+		LogName: "projects/p/logs/stdout",
+		Payload: &loggingpb.LogEntry_TextPayload{
+			TextPayload: "test log",
+		},
+		Severity:  ltype.LogSeverity_ERROR,
+		Timestamp: timestamppb.New(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	basic := LogQueryEntry{
+		Timestamp: "2023-01-01T00:00:00Z",
+		Severity:  "ERROR",
+		LogName:   "projects/p/logs/stdout",
+		Message:   "test log",
+	}
+
+	tests := []struct {
+		name    string
+		req     LogQueryRequest
+		entries []*loggingpb.LogEntry
+		want    []LogQueryEntry
+	}{
+		{
+			name:    "basic view",
+			req:     LogQueryRequest{ProjectID: "p"},
+			entries: []*loggingpb.LogEntry{entry},
+			want:    []LogQueryEntry{basic},
+		},
+		{
+			name:    "full view",
+			req:     LogQueryRequest{ProjectID: "p", View: "FULL"},
+			entries: []*loggingpb.LogEntry{entry},
+			want: []LogQueryEntry{{
+				Timestamp: basic.Timestamp,
+				Severity:  basic.Severity,
+				LogName:   basic.LogName,
+				Message:   basic.Message,
+				Entry: map[string]any{
+					"logName":     "projects/p/logs/stdout",
+					"textPayload": "test log",
+					"severity":    "ERROR",
+					"timestamp":   "2023-01-01T00:00:00Z",
+				},
+			}},
+		},
+		{
+			name:    "format template",
+			req:     LogQueryRequest{ProjectID: "p", Format: "{{.textPayload}}"},
+			entries: []*loggingpb.LogEntry{entry},
+			want: []LogQueryEntry{{
+				Timestamp: basic.Timestamp,
+				Severity:  basic.Severity,
+				LogName:   basic.LogName,
+				Message:   basic.Message,
+				Text:      "test log",
+			}},
+		},
+		{
+			name: "no entries",
+			req:  LogQueryRequest{ProjectID: "p"},
+			want: []LogQueryEntry{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, err := formatLogEntries(&tt.req, "severity>=ERROR", tt.entries, true)
+			if err != nil {
+				t.Fatalf("formatLogEntries() error = %v", err)
+			}
+			if got.ProjectID != "p" || got.Filter != "severity>=ERROR" || !got.Truncated {
+				t.Errorf("formatLogEntries() = %+v, want project p, the filter and truncated", got)
+			}
+			if diff := cmp.Diff(tt.want, got.Entries); diff != "" {
+				t.Errorf("formatLogEntries() entries mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -37,8 +39,8 @@ var supportedLogTypes = map[string]bool{
 	"k8s_event_logs":       true,
 }
 
-func installGetLogSchemas(s *mcp.Server) {
-	mcp.AddTool(s, &mcp.Tool{
+func installGetLogSchemas(s *mcp.Server, c *config.Config) {
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "get_log_schema",
 		Description: "Get the schema for a specific log type.",
 		Annotations: &mcp.ToolAnnotations{
@@ -20,12 +20,12 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
 
 	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
 	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/iterator"
@@ -56,7 +56,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		c: c,
 	}
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "list_monitored_resource_descriptors",
 		Description: "List monitored resource descriptors(schema) related to GKE for this project. Prefer to use this tool instead of gcloud",
 		Annotations: &mcp.ToolAnnotations{
@@ -82,13 +82,13 @@ func (h *handlers) listMRDescriptor(ctx context.Context, _ *mcp.CallToolRequest,
 	if args.ProjectID == "" {
 		return nil, nil, fmt.Errorf("project_id argument cannot be empty")
 	}
-	c, err := monitoring.NewMetricClient(ctx, option.WithUserAgent(h.c.UserAgent()))
+	c, err := monitoring.NewMetricClient(ctx, h.c.GoogleClientOptions()...)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer func() {
 		if err := c.Close(); err != nil {
-			log.Printf("Failed to close monitoring client: %v\n", err)
+			serverlog.Warningf(ctx, "Failed to close monitoring client: %v\n", err)
 		}
 	}()
 	req := &monitoringpb.ListMonitoredResourceDescriptorsRequest{
@@ -122,11 +122,13 @@ func (h *handlers) queryPrometheus(ctx context.Context, _ *mcp.CallToolRequest,
 		return nil, nil, fmt.Errorf("query argument cannot be empty")
 	}
 
-	opts := []option.ClientOption{
-		option.WithUserAgent(h.c.UserAgent()),
-	}
+	var endpointOpts []option.ClientOption
 	if h.endpoint != "" {
-		opts = append(opts, option.WithEndpoint(h.endpoint), option.WithoutAuthentication())
+		endpointOpts = []option.ClientOption{option.WithEndpoint(h.endpoint), option.WithoutAuthentication()}
+	}
+	opts, err := h.c.GoogleRESTClientOptions(ctx, endpointOpts...)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	svc, err := monitoringv1.NewService(ctx, opts...)
@@ -17,6 +17,7 @@ package params
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -79,6 +80,46 @@ func (c *Cluster) ClusterPath() string {
 	return fmt.Sprintf("%s/clusters/%s", c.LocationPath(), c.ClusterName)
 }
 
+// KubeContextPrefix starts the cluster path of a kubeconfig context, followed
+// by the context name.
+const KubeContextPrefix = "kubeconfig/contexts/"
+
+// KubeCluster identifies the cluster of a Kubernetes tool: a GKE cluster, or
+// any context of the server's kubeconfig.
+type KubeCluster struct {
+	ProjectID   string `json:"project_id,omitempty" jsonschema:"GCP project ID of the GKE cluster. Required unless kube_context is set."`
+	Location    string `json:"location,omitempty" jsonschema:"GCP region or zone of the GKE cluster. Required unless kube_context is set."`
+	ClusterName string `json:"cluster_name,omitempty" jsonschema:"GKE cluster name. Required unless kube_context is set."`
+	KubeContext string `json:"kube_context,omitempty" jsonschema:"Optional. Name of a context in the server's kubeconfig to use instead of a GKE cluster, e.g. for kind, minikube or attached clusters."`
+}
+
+// ClusterPath returns the path of the kubeconfig context if KubeContext is
+// set, or else of the GKE cluster. It is empty if no cluster is set, which
+// selects the server's default context, and an error if only some of the
+// fields of the GKE cluster are set.
+func (k *KubeCluster) ClusterPath() (string, error) {
+	if k.KubeContext != "" {
+		return KubeContextPrefix + k.KubeContext, nil
+	}
+	if k.ProjectID == "" && k.Location == "" && k.ClusterName == "" {
+		return "", nil
+	}
+	var missing []string
+	for _, f := range []struct{ name, value string }{
+		{"project_id", k.ProjectID},
+		{"location", k.Location},
+		{"cluster_name", k.ClusterName},
+	} {
+		if f.value == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing %s of the GKE cluster: set project_id, location and cluster_name, or kube_context", strings.Join(missing, ", "))
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s", k.ProjectID, k.Location, k.ClusterName), nil
+}
+
 // NodePool represents GKE node pool parameters.
 type NodePool struct {
 	Cluster
@@ -18,15 +18,15 @@ package recommendation
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
 	recommender "cloud.google.com/go/recommender/apiv1"
 	recommenderpb "cloud.google.com/go/recommender/apiv1/recommenderpb"
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/serverlog"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/iterator"
-	"google.golang.org/api/option"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -46,7 +46,7 @@ func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 		c: c,
 	}
 
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "list_recommendations",
 		Description: "List recommendations for GKE. Prefer to use this tool instead of gcloud",
 		Annotations: &mcp.ToolAnnotations{
@@ -67,13 +67,13 @@ func (h *handlers) listProjectRecommendations(ctx context.Context, _ *mcp.CallTo
 	if args.Location == "" {
 		return nil, nil, fmt.Errorf("location argument not set")
 	}
-	c, err := recommender.NewClient(ctx, option.WithUserAgent(h.c.UserAgent()))
+	c, err := recommender.NewClient(ctx, h.c.GoogleClientOptions()...)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer func() {
 		if err := c.Close(); err != nil {
-			log.Printf("Failed to close recommender client: %v\n", err)
+			serverlog.Warningf(ctx, "Failed to close recommender client: %v\n", err)
 		}
 	}()
 
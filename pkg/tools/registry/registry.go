@@ -56,30 +56,85 @@ type caseMockData struct {
 	K8sResources               []k8sResourceMockRule `json:"k8s_resources,omitempty"`
 }
 
+// AddTool wraps mcp.AddTool, skipping tools that are disabled by the
+// configuration (see ToolEnabled). Tools with a MockMode implementation should
+// use RegisterTool instead.
+func AddTool[In, Out any](
+	s *mcp.Server,
+	c *config.Config,
+	tool *mcp.Tool,
+	handler func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, Out, error),
+) {
+	if !ToolEnabled(c, tool) {
+		return
+	}
+	mcp.AddTool(s, tool, handler)
+}
+
+// ToolEnabled reports whether the configuration allows tool to be registered.
+// In read-only mode only tools annotated with ReadOnlyHint are allowed, and
+// tools must also pass the configured tool filter.
+func ToolEnabled(c *config.Config, tool *mcp.Tool) bool {
+	if c == nil {
+		return true
+	}
+	if c.ReadOnly() && (tool.Annotations == nil || !tool.Annotations.ReadOnlyHint) {
+		return false
+	}
+	return c.ToolFilter().Allows(tool.Name)
+}
+
+// ResourceEnabled reports whether the configuration allows resources that
+// expose the same data as the read-only tool named tool to be registered. They
+// are allowed if the tool is, so that disabling a tool also hides its data.
+func ResourceEnabled(c *config.Config, tool string) bool {
+	return ToolEnabled(c, &mcp.Tool{Name: tool, Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}})
+}
+
+// AddResource wraps mcp.Server.AddResource, skipping resources whose read-only
+// tool is disabled by the configuration (see ResourceEnabled).
+func AddResource(s *mcp.Server, c *config.Config, tool string, r *mcp.Resource, handler mcp.ResourceHandler) {
+	if !ResourceEnabled(c, tool) {
+		return
+	}
+	s.AddResource(r, handler)
+}
+
+// AddResourceTemplate wraps mcp.Server.AddResourceTemplate, skipping resource
+// templates whose read-only tool is disabled by the configuration (see
+// ResourceEnabled).
+func AddResourceTemplate(s *mcp.Server, c *config.Config, tool string, t *mcp.ResourceTemplate, handler mcp.ResourceHandler) {
+	if !ResourceEnabled(c, tool) {
+		return
+	}
+	s.AddResourceTemplate(t, handler)
+}
+
 // RegisterTool wraps mcp.AddTool to intercept and mock tool execution in MockMode.
 //
 // When Config.MockMode() is active, the real tool handler is bypassed, and
 // execution is routed through handleClusterEncodedMock to fetch simulated
-// telemetry responses from the filesystem workspace. If MockMode is disabled,
-// it delegates directly to the production handler.
+// telemetry responses from the filesystem workspace. Mock results carry no
+// structured content, which could contradict the mock text, so the tool is
+// registered without an output schema. If MockMode is disabled, it delegates
+// directly to the production handler. Tools disabled by the configuration are
+// not registered.
 func RegisterTool[In, Out any](
 	s *mcp.Server,
 	c *config.Config,
 	tool *mcp.Tool,
 	handler func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, Out, error),
 ) {
-	mcp.AddTool(s, tool, func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, Out, error) {
-		if c != nil && c.MockMode() {
-			res, _, err := handleMockToolCall(ctx, tool.Name, args, c)
-			if err != nil {
-				var zero Out
-				return nil, zero, err
-			}
-			var zero Out
-			return res, zero, nil
-		}
-		return handler(ctx, req, args)
-	})
+	if !ToolEnabled(c, tool) {
+		return
+	}
+	if c != nil && c.MockMode() {
+		mcp.AddTool(s, tool, func(ctx context.Context, _ *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+			return handleMockToolCall(ctx, tool.Name, args, c)
+		})
+		return
+	}
+	mcp.AddTool(s, tool, handler)
 }
 
 // handleMockToolCall routes mock tool execution to the appropriate mock data handler.
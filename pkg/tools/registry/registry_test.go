@@ -537,6 +537,61 @@ func TestRegisterTool_MockModeToggle(t *testing.T) {
 		}
 	})
 
+	t.Run("mock mode omits structured output", func(t *testing.T) {
+		type dummyOutput struct {
+			Resources []string `json:"resources"`
+		}
+		prodHandler := func(_ context.Context, _ *mcp.CallToolRequest, _ dummyArgs) (*mcp.CallToolResult, *dummyOutput, error) {
+			return nil, &dummyOutput{Resources: []string{"prod"}}, nil
+		}
+
+		t.Setenv("GKE_MCP_MOCK", "true")
+		t.Setenv("GKE_MCP_MOCK_DATA_DIR", tempDir)
+		t.Setenv("GKE_MCP_MOCK_SKILL", "my-skill")
+		t.Setenv("GKE_MCP_MOCK_CASE", "my_case")
+		cfg := config.New("test", false)
+
+		server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+		RegisterTool(server, cfg, tool, prodHandler)
+
+		clientTransport, serverTransport := mcp.NewInMemoryTransports()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			_ = server.Run(ctx, serverTransport)
+		}()
+
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+		session, err := client.Connect(ctx, clientTransport, nil)
+		if err != nil {
+			t.Fatalf("failed to connect client: %v", err)
+		}
+		defer func() { _ = session.Close() }()
+
+		tools, err := session.ListTools(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListTools failed: %v", err)
+		}
+		if len(tools.Tools) != 1 || tools.Tools[0].OutputSchema != nil {
+			t.Errorf("expected query_logs to be listed without an output schema in mock mode")
+		}
+
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      "query_logs",
+			Arguments: map[string]any{"cluster_name": "tpu-prod", "query": "error"},
+		})
+		if err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		if res.StructuredContent != nil {
+			t.Errorf("got structured content %v, want none in mock mode", res.StructuredContent)
+		}
+		if len(res.Content) != 1 || res.Content[0].(*mcp.TextContent).Text != "mock response" {
+			t.Errorf("got content %v, want only the mock response", res.Content)
+		}
+	})
+
 	t.Run("nil config safely delegates to prod handler", func(t *testing.T) {
 		prodHandlerCalled := false
 		prodHandler := func(_ context.Context, _ *mcp.CallToolRequest, _ dummyArgs) (*mcp.CallToolResult, any, error) {
@@ -650,3 +705,42 @@ func TestRegisterTool_MockModeToggle(t *testing.T) {
 		}
 	})
 }
+
+func TestToolEnabled(t *testing.T) {
+	readOnlyTool := &mcp.Tool{Name: "list_things", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}
+	mutatingTool := &mcp.Tool{Name: "update_thing", Annotations: &mcp.ToolAnnotations{}}
+	unannotatedTool := &mcp.Tool{Name: "do_thing"}
+
+	tests := []struct {
+		name string
+		c    *config.Config
+		tool *mcp.Tool
+		want bool
+	}{
+		{name: "nil config", c: nil, tool: mutatingTool, want: true},
+		{name: "default allows mutating", c: config.NewTestConfig("p", "l", "", ""), tool: mutatingTool, want: true},
+		{name: "read-only allows read-only", c: config.NewTestConfig("p", "l", "", "", config.WithReadOnly(true)), tool: readOnlyTool, want: true},
+		{name: "read-only rejects mutating", c: config.NewTestConfig("p", "l", "", "", config.WithReadOnly(true)), tool: mutatingTool, want: false},
+		{name: "read-only rejects unannotated", c: config.NewTestConfig("p", "l", "", "", config.WithReadOnly(true)), tool: unannotatedTool, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ToolEnabled(tc.c, tc.tool); got != tc.want {
+				t.Errorf("ToolEnabled() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourceEnabled(t *testing.T) {
+	c := config.NewTestConfig("p", "l", "", "",
+		config.WithReadOnly(true),
+		config.WithToolFilter(config.ToolFilter{Disable: []string{"list_*"}}))
+	if !ResourceEnabled(c, "get_cluster") {
+		t.Error("ResourceEnabled(get_cluster) = false, want true in read-only mode")
+	}
+	if ResourceEnabled(c, "list_clusters") {
+		t.Error("ResourceEnabled(list_clusters) = true, want false when the tool is disabled")
+	}
+}
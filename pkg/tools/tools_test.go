@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/apps"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// fakeCredentials points Application Default Credentials at a dummy file so
+// that Google API clients can be constructed without contacting any server.
+func fakeCredentials(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "adc.json")
+	creds := `{"type": "authorized_user", "client_id": "id", "client_secret": "secret", "refresh_token": "token"}`
+	if err := os.WriteFile(path, []byte(creds), 0600); err != nil {
+		t.Fatalf("failed to write credentials: %v", err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+}
+
+// installedTools installs all tools and apps with the given config and returns
+// the tools advertised to a client, keyed by name.
+func installedTools(t *testing.T, c *config.Config) map[string]*mcp.Tool {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	if err := Install(ctx, server, c); err != nil {
+		t.Fatalf("Install() failed: %v", err)
+	}
+	if err := apps.InstallApps(ctx, server, c); err != nil {
+		t.Fatalf("InstallApps() failed: %v", err)
+	}
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = server.Run(ctx, serverTransport)
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	tools := make(map[string]*mcp.Tool)
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			t.Fatalf("failed to list tools: %v", err)
+		}
+		tools[tool.Name] = tool
+	}
+	return tools
+}
+
+func isReadOnly(tool *mcp.Tool) bool {
+	return tool.Annotations != nil && tool.Annotations.ReadOnlyHint
+}
+
+func TestInstall_ReadOnly(t *testing.T) {
+	fakeCredentials(t)
+
+	all := installedTools(t, config.NewTestConfig("test-project", "us-central1", "vertex-ai", "gemini-2.5-pro"))
+	readOnly := installedTools(t, config.NewTestConfig("test-project", "us-central1", "vertex-ai", "gemini-2.5-pro", config.WithReadOnly(true)))
+
+	var want, got []string
+	for name, tool := range all {
+		if isReadOnly(tool) {
+			want = append(want, name)
+		}
+	}
+	for name, tool := range readOnly {
+		if !isReadOnly(tool) {
+			t.Errorf("tool %q is registered in read-only mode but is not annotated as read-only", name)
+		}
+		got = append(got, name)
+	}
+	slices.Sort(want)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("read-only tools = %v, want %v", got, want)
+	}
+
+	mutating := []string{
+		"create_cluster",
+		"update_cluster",
+		"create_node_pool",
+		"update_node_pool",
+		"cancel_operation",
+		"get_kubeconfig",
+		"get_node_sos_report",
+		"apply_k8s_manifest",
+		"patch_k8s_resource",
+		"delete_k8s_resource",
+	}
+	for _, name := range mutating {
+		if _, ok := all[name]; !ok {
+			t.Errorf("tool %q is not registered by default", name)
+		}
+		if _, ok := readOnly[name]; ok {
+			t.Errorf("mutating tool %q is registered in read-only mode", name)
+		}
+	}
+	for _, name := range []string{"list_clusters", "get_k8s_resource", "query_logs"} {
+		if _, ok := readOnly[name]; !ok {
+			t.Errorf("read-only tool %q is not registered in read-only mode", name)
+		}
+	}
+}
+
+func TestInstall_ToolFilter(t *testing.T) {
+	fakeCredentials(t)
+
+	filter := config.ToolFilter{
+		Enable:  []string{"*_node_pool", "*_k8s_*", "generate_manifest", "dropdown"},
+		Disable: []string{"delete_*", "apply_k8s_manifest"},
+	}
+	tools := installedTools(t, config.NewTestConfig("test-project", "us-central1", "vertex-ai", "gemini-2.5-pro", config.WithToolFilter(filter)))
+
+	for _, name := range []string{"get_node_pool", "create_node_pool", "get_k8s_resource", "list_k8s_events", "generate_manifest", "dropdown"} {
+		if _, ok := tools[name]; !ok {
+			t.Errorf("tool %q is not registered, want registered", name)
+		}
+	}
+	for name := range tools {
+		if !filter.Allows(name) {
+			t.Errorf("tool %q is registered but not allowed by the filter", name)
+		}
+	}
+}
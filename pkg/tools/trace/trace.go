@@ -24,16 +24,16 @@ import (
 	"time"
 
 	"google.golang.org/api/cloudtrace/v1"
-	"google.golang.org/api/option"
 
 	"github.com/GoogleCloudPlatform/gke-mcp/pkg/config"
+	"github.com/GoogleCloudPlatform/gke-mcp/pkg/tools/registry"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // Install registers trace tools with the MCP server.
 func Install(_ context.Context, s *mcp.Server, c *config.Config) error {
 	h := &handlers{c: c}
-	mcp.AddTool(s, &mcp.Tool{
+	registry.AddTool(s, c, &mcp.Tool{
 		Name:        "query_traces",
 		Description: "Query Google Cloud Trace to retrieve traces for troubleshooting latency or distributed requests. You can specify time ranges, limits, and a filter.",
 		Annotations: &mcp.ToolAnnotations{
@@ -55,7 +55,11 @@ func (h *handlers) getService(ctx context.Context) (*cloudtrace.Service, error)
 	if h.svc != nil {
 		return h.svc, nil
 	}
-	svc, err := cloudtrace.NewService(ctx, option.WithUserAgent(h.c.UserAgent()))
+	opts, err := h.c.GoogleRESTClientOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := cloudtrace.NewService(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cloudtrace service: %w", err)
 	}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing instruments MCP tool calls and the Kubernetes requests made
+// on their behalf with OpenTelemetry spans.
+//
+// Google Cloud API clients already create spans through the global tracer
+// provider, so installing the provider returned by NewProvider with
+// otel.SetTracerProvider is enough for them to appear as children of the tool
+// call span.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "gke-mcp"
+	scopeName   = "github.com/GoogleCloudPlatform/gke-mcp/pkg/tracing"
+)
+
+// NewExporter returns an exporter sending spans over OTLP/gRPC to endpoint, a
+// URL such as "http://localhost:4317". Plain text is used for http:// URLs.
+func NewExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// NewProvider returns a tracer provider batching spans to exporter. Callers
+// must call Shutdown on it to flush pending spans.
+func NewProvider(exporter sdktrace.SpanExporter, version string) *sdktrace.TracerProvider {
+	res := resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", version),
+	)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+}
+
+// Tracer creates spans for MCP tool calls and Kubernetes API requests.
+type Tracer struct {
+	provider trace.TracerProvider
+	tracer   trace.Tracer
+}
+
+// New returns a Tracer creating spans with provider.
+func New(provider trace.TracerProvider) *Tracer {
+	return &Tracer{provider: provider, tracer: provider.Tracer(scopeName)}
+}
+
+// Middleware is MCP server middleware that wraps every tools/call request in
+// a span. Install it with mcp.Server.AddReceivingMiddleware.
+func (t *Tracer) Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		callReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		attrs := []attribute.KeyValue{
+			attribute.String("mcp.method.name", method),
+			attribute.String("gen_ai.tool.name", callReq.Params.Name),
+		}
+		if callReq.Session != nil {
+			attrs = append(attrs, attribute.String("mcp.session.id", callReq.Session.ID()))
+		}
+		ctx, span := t.tracer.Start(ctx, method+" "+callReq.Params.Name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...))
+		defer span.End()
+
+		res, err := next(ctx, method, req)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if r, ok := res.(*mcp.CallToolResult); ok && r != nil && r.IsError {
+			msg := "tool returned an error"
+			if toolErr := r.GetError(); toolErr != nil {
+				span.RecordError(toolErr)
+				msg = toolErr.Error()
+			}
+			span.SetStatus(codes.Error, msg)
+		}
+		return res, err
+	}
+}
+
+// WrapKubernetesTransport wraps rt to create a span for every Kubernetes API
+// request. It is suitable for use with rest.Config.Wrap.
+func (t *Tracer) WrapKubernetesTransport(rt http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(rt,
+		otelhttp.WithTracerProvider(t.provider),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return "kubernetes " + r.Method
+		}))
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type getArgs struct {
+	Fail bool `json:"fail"`
+}
+
+func TestTracer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+	tracer := New(provider)
+	k8sClient := &http.Client{Transport: tracer.WrapKubernetesTransport(http.DefaultTransport)}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(tracer.Middleware)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_k8s_resource"}, func(ctx context.Context, _ *mcp.CallToolRequest, args getArgs) (*mcp.CallToolResult, any, error) {
+		if args.Fail {
+			return nil, nil, errors.New("forbidden")
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiServer.URL+"/api/v1/pods", nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		resp, err := k8sClient.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		_ = resp.Body.Close()
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil, nil
+	})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = server.Run(ctx, serverTransport)
+	}()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	for _, fail := range []bool{false, true} {
+		if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get_k8s_resource", Arguments: map[string]any{"fail": fail}}); err != nil {
+			t.Fatalf("CallTool() failed: %v", err)
+		}
+	}
+
+	spans := exporter.GetSpans()
+	byName := make(map[string][]tracetest.SpanStub)
+	for _, s := range spans {
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+	toolSpans := byName["tools/call get_k8s_resource"]
+	k8sSpans := byName["kubernetes GET"]
+	if len(toolSpans) != 2 || len(k8sSpans) != 1 {
+		t.Fatalf("got spans %v, want 2 tool call spans and 1 Kubernetes span", byName)
+	}
+
+	ok, failed := toolSpans[0], toolSpans[1]
+	if k8sSpans[0].Parent.SpanID() != ok.SpanContext.SpanID() {
+		t.Errorf("Kubernetes span parent = %v, want tool call span %v", k8sSpans[0].Parent.SpanID(), ok.SpanContext.SpanID())
+	}
+	if ok.Status.Code == codes.Error {
+		t.Errorf("successful tool call span status = %v, want unset", ok.Status)
+	}
+	if failed.Status.Code != codes.Error || failed.Status.Description != "forbidden" {
+		t.Errorf("failed tool call span status = %v, want error 'forbidden'", failed.Status)
+	}
+	var toolName string
+	for _, attr := range ok.Attributes {
+		if attr.Key == "gen_ai.tool.name" {
+			toolName = attr.Value.AsString()
+		}
+	}
+	if toolName != "get_k8s_resource" {
+		t.Errorf("gen_ai.tool.name = %q, want get_k8s_resource", toolName)
+	}
+}